@@ -0,0 +1,217 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bundle exports everything required to bootstrap an unmanaged cluster into a single
+// archive, and imports such an archive so clusters can be created without access to the
+// registries that host the Tanzu Kubernetes Release (TKR) and its packages.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	manifestFileName = "manifest.yaml"
+	bomDir           = "bom"
+	imagesDir        = "images"
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	// TkrLocation is the TKR the bundle was exported for.
+	TkrLocation string `yaml:"TkrLocation"`
+	// BomFile is the path of the TKR BOM within the bundle.
+	BomFile string `yaml:"BomFile"`
+	// NodeImage is the node image of the TKR, saved as a docker image archive.
+	NodeImage Artifact `yaml:"NodeImage"`
+	// RegistryImage is the image of the registry the bundle is imported into, saved as a docker
+	// image archive.
+	RegistryImage Artifact `yaml:"RegistryImage"`
+	// KappBundle is the kapp-controller bundle of the TKR, saved as an imgpkg archive.
+	KappBundle Artifact `yaml:"KappBundle"`
+	// CoreRepo is the core package repository of the TKR, saved as an imgpkg archive.
+	CoreRepo Artifact `yaml:"CoreRepo"`
+	// AdditionalRepos are the additional package repositories, saved as imgpkg archives.
+	AdditionalRepos []Artifact `yaml:"AdditionalRepos"`
+}
+
+// Artifact is an image, or image bundle, stored in a bundle.
+type Artifact struct {
+	// Ref is the reference the artifact was exported from.
+	Ref string `yaml:"Ref"`
+	// Path is the path of the artifact's archive within the bundle.
+	Path string `yaml:"Path"`
+	// Relocated is the reference of the artifact once it was pushed to a local registry. It is
+	// only set for imgpkg archives, once the bundle is imported.
+	Relocated string `yaml:"Relocated,omitempty"`
+}
+
+// ReadManifest reads the manifest of a bundle extracted to the directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading bundle manifest. Error: %s", err.Error())
+	}
+
+	m := &Manifest{}
+	err = yaml.Unmarshal(data, m)
+	if err != nil {
+		return nil, fmt.Errorf("bundle manifest was invalid. Error: %s", err.Error())
+	}
+	return m, nil
+}
+
+// BomPath returns the path of the TKR BOM of a bundle extracted to the directory.
+func BomPath(dir string, m *Manifest) string {
+	return filepath.Join(dir, filepath.FromSlash(m.BomFile))
+}
+
+// writeManifest writes the manifest of a bundle to the directory.
+func writeManifest(dir string, m *Manifest) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to render bundle manifest. Error: %s", err.Error())
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFileName), data, 0644)
+}
+
+// Extract unpacks a bundle archive to the directory.
+func Extract(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle %s. Error: %s", archivePath, err.Error())
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("bundle %s is not a gzipped archive. Error: %s", archivePath, err.Error())
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed reading bundle %s. Error: %s", archivePath, err.Error())
+		}
+
+		// Refuse entries that would be written outside of the directory
+		target := filepath.Join(dir, filepath.Clean(header.Name))
+		if target != filepath.Clean(dir) && !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("bundle %s contains invalid path %s", archivePath, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			err = extractFile(tr, target)
+		default:
+			err = fmt.Errorf("unsupported entry type for %s", header.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed extracting bundle %s. Error: %s", archivePath, err.Error())
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r) //nolint:gosec
+	return err
+}
+
+// archive packs the contents of the directory into a gzipped tar archive.
+func archive(dir, archivePath string) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle %s. Error: %s", archivePath, err.Error())
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		err = tw.WriteHeader(header)
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write bundle %s. Error: %s", archivePath, err.Error())
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write bundle %s. Error: %s", archivePath, err.Error())
+	}
+	return gz.Close()
+}
+
+// artifactPath returns the path within a bundle to store the archive of an image reference in.
+func artifactPath(ref string) string {
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(ref)
+	return filepath.ToSlash(filepath.Join(imagesDir, name+".tar"))
+}
+
+// repositoryPath returns the repository of an image reference, without the registry host, tag
+// or digest. For example, projects.registry.vmware.com/tce/main:v0.11.0 returns tce/main.
+func repositoryPath(ref string) string {
+	repo := ref
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo = repo[:i]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	if i := strings.Index(repo, "/"); i >= 0 {
+		host := repo[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			repo = repo[i+1:]
+		}
+	}
+	return repo
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepositoryPath(t *testing.T) {
+	tests := map[string]string{
+		"projects.registry.vmware.com/tce/main:v0.11.0":           "tce/main",
+		"projects.registry.vmware.com/tce/kapp@sha256:abc":        "tce/kapp",
+		"localhost:5000/tce/main:v0.11.0":                         "tce/main",
+		"library/registry:2":                                      "library/registry",
+		"registry:2":                                              "registry",
+		"projects.registry.vmware.com/tce/repo-12:1.2.3@sha256:a": "tce/repo-12",
+	}
+
+	for ref, expected := range tests {
+		if repo := repositoryPath(ref); repo != expected {
+			t.Errorf("expected repository of %s to be %s, was: %s", ref, expected, repo)
+		}
+	}
+}
+
+func TestArchiveAndExtract(t *testing.T) {
+	srcDir := t.TempDir()
+	m := &Manifest{
+		TkrLocation: "projects.registry.vmware.com/tce/tkr:v0.17.0",
+		BomFile:     "bom/tkr-bom.yaml",
+		CoreRepo: Artifact{
+			Ref:  "projects.registry.vmware.com/tce/repo:v0.12.0",
+			Path: artifactPath("projects.registry.vmware.com/tce/repo:v0.12.0"),
+		},
+	}
+	err := os.MkdirAll(filepath.Join(srcDir, "bom"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(srcDir, m.BomFile), []byte("bom"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeManifest(srcDir, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	err = archive(srcDir, archivePath)
+	if err != nil {
+		t.Fatalf("failed to archive bundle: %s", err.Error())
+	}
+
+	dstDir := t.TempDir()
+	err = Extract(archivePath, dstDir)
+	if err != nil {
+		t.Fatalf("failed to extract bundle: %s", err.Error())
+	}
+
+	extracted, err := ReadManifest(dstDir)
+	if err != nil {
+		t.Fatalf("failed to read extracted manifest: %s", err.Error())
+	}
+	if extracted.TkrLocation != m.TkrLocation {
+		t.Errorf("expected TkrLocation %s, was: %s", m.TkrLocation, extracted.TkrLocation)
+	}
+	if extracted.CoreRepo.Path != "images/projects.registry.vmware.com_tce_repo_v0.12.0.tar" {
+		t.Errorf("unexpected core repo path: %s", extracted.CoreRepo.Path)
+	}
+	bom, err := os.ReadFile(BomPath(dstDir, extracted))
+	if err != nil {
+		t.Fatalf("failed to read extracted BOM: %s", err.Error())
+	}
+	if string(bom) != "bom" {
+		t.Errorf("unexpected BOM contents: %s", string(bom))
+	}
+}
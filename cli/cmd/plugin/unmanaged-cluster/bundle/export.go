@@ -0,0 +1,176 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
+)
+
+const bomFileName = "tkr-bom.yaml"
+
+// ExportOptions are the options to export a bundle with.
+type ExportOptions struct {
+	// TkrLocation is the TKR to export.
+	TkrLocation string
+	// AdditionalRepos are the additional package repositories to export.
+	AdditionalRepos []string
+	// RegistryImage is the image of the registry the bundle is imported into.
+	RegistryImage string
+	// OutputPath is the path the bundle archive is written to.
+	OutputPath string
+	// Progress is called as each artifact is exported. It may be nil.
+	Progress func(ref string)
+}
+
+// Export writes everything required to bootstrap a cluster from the TKR to a bundle archive: the
+// TKR BOM, the node image, the kapp-controller bundle, the package repositories along with the
+// images of their packages, and the image of the registry they are served from.
+func Export(opts *ExportOptions) error {
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	// Stage the bundle next to its output, as the images may not fit in the temp directory
+	stagingDir, err := os.MkdirTemp(filepath.Dir(opts.OutputPath), ".bundle-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory. Error: %s", err.Error())
+	}
+	defer os.RemoveAll(stagingDir)
+
+	m := &Manifest{
+		TkrLocation: opts.TkrLocation,
+		BomFile:     filepath.ToSlash(filepath.Join(bomDir, bomFileName)),
+	}
+
+	progress(opts.TkrLocation)
+	bom, err := exportBom(opts.TkrLocation, filepath.Join(stagingDir, m.BomFile))
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Join(stagingDir, imagesDir), 0755)
+	if err != nil {
+		return err
+	}
+
+	progress(bom.GetTKRNodeImage())
+	m.NodeImage, err = exportImage(stagingDir, bom.GetTKRNodeImage())
+	if err != nil {
+		return err
+	}
+	progress(opts.RegistryImage)
+	m.RegistryImage, err = exportImage(stagingDir, opts.RegistryImage)
+	if err != nil {
+		return err
+	}
+
+	kappBundle, err := bom.GetTKRKappImage()
+	if err != nil {
+		return err
+	}
+	progress(kappBundle.GetRegistryURL())
+	m.KappBundle, err = exportBundle(stagingDir, kappBundle.GetRegistryURL())
+	if err != nil {
+		return err
+	}
+
+	progress(bom.GetTKRCoreRepoBundlePath())
+	m.CoreRepo, err = exportBundle(stagingDir, bom.GetTKRCoreRepoBundlePath())
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range opts.AdditionalRepos {
+		progress(repo)
+		artifact, err := exportBundle(stagingDir, repo)
+		if err != nil {
+			return err
+		}
+		m.AdditionalRepos = append(m.AdditionalRepos, artifact)
+	}
+
+	err = writeManifest(stagingDir, m)
+	if err != nil {
+		return err
+	}
+	return archive(stagingDir, opts.OutputPath)
+}
+
+// exportBom downloads the BOM of the TKR to the path and parses it.
+func exportBom(tkrLocation, path string) (*tkr.Bom, error) {
+	bomImage, err := tkr.NewTkrImageReader(tkrLocation)
+	if err != nil {
+		return nil, err
+	}
+	err = bomImage.DownloadImage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download TKR %s. Error: %s", tkrLocation, err.Error())
+	}
+	defer os.RemoveAll(bomImage.GetDownloadPath())
+
+	files, err := os.ReadDir(bomImage.GetDownloadPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read downloaded TKR BOM files. Error: %s", err.Error())
+	}
+	if len(files) != 1 {
+		return nil, fmt.Errorf("more than one file found in TKR BOM image. Expected 1 file: %s", bomImage.GetDownloadPath())
+	}
+
+	err = copyFile(filepath.Join(bomImage.GetDownloadPath(), files[0].Name()), path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy TKR BOM. Error: %s", err.Error())
+	}
+	return tkr.ReadTKRBom(path)
+}
+
+// exportImage saves a container image, pulled through docker, to the bundle.
+func exportImage(stagingDir, image string) (Artifact, error) {
+	artifact := Artifact{Ref: image, Path: artifactPath(image)}
+
+	cmd := exec.Command("docker", "pull", image)
+	_, err := exec.Output(cmd)
+	if err != nil {
+		return artifact, fmt.Errorf("failed to pull image %s. Error: %s", image, err.Error())
+	}
+	cmd = exec.Command("docker", "save", "--output", filepath.Join(stagingDir, artifact.Path), image)
+	_, err = exec.Output(cmd)
+	if err != nil {
+		return artifact, fmt.Errorf("failed to save image %s. Error: %s", image, err.Error())
+	}
+	return artifact, nil
+}
+
+// exportBundle saves an imgpkg bundle, along with the images it references, to the bundle.
+func exportBundle(stagingDir, ref string) (Artifact, error) {
+	artifact := Artifact{Ref: ref, Path: artifactPath(ref)}
+	err := copyBundleToTar(ref, filepath.Join(stagingDir, artifact.Path))
+	return artifact, err
+}
+
+func copyFile(src, dst string) error {
+	err := os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return err
+}
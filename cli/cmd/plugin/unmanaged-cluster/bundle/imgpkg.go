@@ -0,0 +1,72 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/k14s/imgpkg/pkg/imgpkg/cmd"
+	"gopkg.in/yaml.v3"
+)
+
+// copyConcurrency is the number of images copied at once, as by default with the imgpkg CLI.
+const copyConcurrency = 5
+
+// bundleLock is the lock file imgpkg writes for a bundle that was copied to a repository.
+type bundleLock struct {
+	Bundle struct {
+		Image string `yaml:"image"`
+	} `yaml:"bundle"`
+}
+
+// copyBundleToTar saves an imgpkg bundle, along with all images it references, to a tarball.
+func copyBundleToTar(ref, tarPath string) error {
+	co := cmd.NewCopyOptions()
+	co.Concurrency = copyConcurrency
+	co.BundleFlags = cmd.BundleFlags{
+		Bundle: ref,
+	}
+	co.TarFlags.TarDst = tarPath
+
+	err := co.Run()
+	if err != nil {
+		return fmt.Errorf("failed to save bundle %s. Error: %s", ref, err.Error())
+	}
+	return nil
+}
+
+// copyTarToRepo pushes a bundle saved by copyBundleToTar to a repository. It returns the reference
+// of the pushed bundle, by digest.
+func copyTarToRepo(tarPath, repo string) (string, error) {
+	lockDir, err := os.MkdirTemp("", "bundle-lock")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(lockDir)
+	lockPath := filepath.Join(lockDir, "lock.yml")
+
+	co := cmd.NewCopyOptions()
+	co.Concurrency = copyConcurrency
+	co.TarFlags.TarSrc = tarPath
+	co.RepoDst = repo
+	co.LockOutputFlags.LockFilePath = lockPath
+
+	err = co.Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to push %s to %s. Error: %s", tarPath, repo, err.Error())
+	}
+
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read lock of pushed bundle. Error: %s", err.Error())
+	}
+	lock := &bundleLock{}
+	err = yaml.Unmarshal(data, lock)
+	if err != nil || lock.Bundle.Image == "" {
+		return "", fmt.Errorf("unable to resolve the pushed bundle from its lock file %s", lockPath)
+	}
+	return lock.Bundle.Image, nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCopyBundleToTarRequiresBundle(t *testing.T) {
+	err := copyBundleToTar("", filepath.Join(t.TempDir(), "bundle.tar"))
+	if err == nil || !strings.Contains(err.Error(), "failed to save bundle") {
+		t.Errorf("expected an error saving a bundle without a reference, got: %v", err)
+	}
+}
+
+func TestCopyTarToRepoInvalidRepo(t *testing.T) {
+	_, err := copyTarToRepo(filepath.Join(t.TempDir(), "bundle.tar"), "INVALID repo")
+	if err == nil || !strings.Contains(err.Error(), "failed to push") {
+		t.Errorf("expected an error pushing to an invalid repository, got: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// Runtime is the container runtime the images of a bundle are loaded into.
+type Runtime interface {
+	// Command returns a command that runs the runtime's CLI.
+	Command(args ...string) exec.Cmd
+}
+
+// LoadImages loads the node and registry images of a bundle extracted to the directory into the
+// container runtime the cluster is created with.
+func LoadImages(runtime Runtime, dir string, m *Manifest) error {
+	for _, artifact := range []Artifact{m.NodeImage, m.RegistryImage} {
+		cmd := runtime.Command("load", "--input", filepath.Join(dir, filepath.FromSlash(artifact.Path)))
+		_, err := exec.Output(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to load image %s. Error: %s", artifact.Ref, err.Error())
+		}
+	}
+	return nil
+}
+
+// Push pushes the kapp-controller bundle and package repositories of a bundle extracted to the
+// directory to a registry, and records their relocated references in the bundle's manifest.
+// Progress is called as each artifact is pushed. It may be nil.
+func Push(dir string, m *Manifest, registryAddress string, progress func(ref string)) error {
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	artifacts := []*Artifact{&m.KappBundle, &m.CoreRepo}
+	for i := range m.AdditionalRepos {
+		artifacts = append(artifacts, &m.AdditionalRepos[i])
+	}
+	for _, artifact := range artifacts {
+		progress(artifact.Ref)
+		repo := fmt.Sprintf("%s/%s", registryAddress, repositoryPath(artifact.Ref))
+		relocated, err := copyTarToRepo(filepath.Join(dir, filepath.FromSlash(artifact.Path)), repo)
+		if err != nil {
+			return err
+		}
+		artifact.Relocated = relocated
+	}
+
+	return writeManifest(dir, m)
+}
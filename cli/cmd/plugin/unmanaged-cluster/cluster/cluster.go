@@ -7,12 +7,42 @@
 package cluster
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
 )
 
 const (
-	NoneClusterManagerProvider = "none"
-	KindClusterManagerProvider = "kind"
+	NoneClusterManagerProvider     = "none"
+	KindClusterManagerProvider     = "kind"
+	MinikubeClusterManagerProvider = "minikube"
+	K3dClusterManagerProvider      = "k3d"
+)
+
+// providers are the names of all supported cluster providers.
+var providers = []string{KindClusterManagerProvider, MinikubeClusterManagerProvider, K3dClusterManagerProvider, NoneClusterManagerProvider}
+
+const (
+	// StatusRunning indicates all nodes of the cluster are running.
+	StatusRunning = "Running"
+	// StatusStopped indicates one or more nodes of the cluster are not running.
+	StatusStopped = "Stopped"
+	// StatusMissing indicates the provider has no nodes for the cluster, such as when they were
+	// removed outside of the unmanaged-cluster plugin.
+	StatusMissing = "Missing"
+	// StatusUnknown indicates the provider is unable to determine the state of the cluster.
+	StatusUnknown = "Unknown"
+)
+
+const (
+	// ControlPlaneRole is the role of nodes running the Kubernetes control plane.
+	ControlPlaneRole = "control-plane"
+	// WorkerRole is the role of nodes running workloads only.
+	WorkerRole = "worker"
 )
 
 // KubernetesCluster represents a defines k8s cluster.
@@ -21,6 +51,59 @@ type KubernetesCluster struct {
 	Name string
 	// KubeConfig contains the Kubeconfig data for the cluster.
 	Kubeconfig []byte
+	// Status is the overall state of the cluster, as reported by the provider.
+	Status string
+	// Endpoint is the address of the cluster's API server.
+	Endpoint string
+	// NodeImage is the image the cluster's nodes run, if known to the provider.
+	NodeImage string
+	// Nodes are the nodes that make up the cluster.
+	Nodes []Node
+	// CreatedAt is when the cluster was created, as reported by the provider. It is the zero time
+	// when it is not known.
+	CreatedAt time.Time
+	// ContextName is the name the provider gives the cluster's context in its kubeconfig. It is set
+	// by Create, and is empty when the provider does not name the context.
+	ContextName string
+}
+
+// Node represents a single node of a cluster.
+type Node struct {
+	// Name is the name of the node.
+	Name string `json:"name" yaml:"name"`
+	// Role is the Kubernetes role of the node, such as control-plane or worker.
+	Role string `json:"role" yaml:"role"`
+	// Image is the image the node runs, if known to the provider.
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	// Status is the state of the node, as reported by the provider.
+	Status string `json:"status" yaml:"status"`
+	// Ports are the ports forwarded from the host to the node (format: '127.0.0.1:80->80/tcp').
+	Ports []string `json:"ports,omitempty" yaml:"ports,omitempty"`
+	// CreatedAt is when the node was created.
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+}
+
+// NodeCounts returns the number of control plane and worker nodes in the cluster. Nodes with
+// other roles, such as load balancers, are not counted.
+func (k *KubernetesCluster) NodeCounts() (controlPlanes, workers int) {
+	for _, n := range k.Nodes {
+		switch n.Role {
+		case ControlPlaneRole:
+			controlPlanes++
+		case WorkerRole:
+			workers++
+		}
+	}
+	return controlPlanes, workers
+}
+
+// setCreatedAt sets the creation time of the cluster to that of its oldest node.
+func (k *KubernetesCluster) setCreatedAt() {
+	for _, n := range k.Nodes {
+		if k.CreatedAt.IsZero() || n.CreatedAt.Before(k.CreatedAt) {
+			k.CreatedAt = n.CreatedAt
+		}
+	}
 }
 
 // Manager provides methods for creating and managing Kubernetes
@@ -29,10 +112,17 @@ type Manager interface {
 	// Create will create a new cluster or return an error indicating a problem
 	// during creation.
 	Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error)
-	// Get retrieves cluster information or return an error indicating a problem.
-	Get(clusterName string) (*KubernetesCluster, error)
+	// Get retrieves cluster information or return an error indicating a problem. A cluster
+	// whose nodes no longer exist is returned with a status of StatusMissing.
+	Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error)
 	// Delete will destroy a cluster or return an error indicating a problem.
 	Delete(c *config.UnmanagedClusterConfig) error
+	// Stop will stop a cluster's nodes, without removing them or their state, or return an error
+	// indicating a problem. Providers that cannot stop clusters return an error.
+	Stop(c *config.UnmanagedClusterConfig) error
+	// Start will start the nodes of a stopped cluster or return an error indicating a problem. It does not
+	// wait for Kubernetes to be available. Providers that cannot start clusters return an error.
+	Start(c *config.UnmanagedClusterConfig) error
 	// Prepare will fetch an image or perform any pre-steps that can be done
 	// prior to actually creating the cluster.
 	Prepare(c *config.UnmanagedClusterConfig) error
@@ -50,13 +140,36 @@ type Manager interface {
 func NewClusterManager(c *config.UnmanagedClusterConfig) Manager {
 	switch c.Provider {
 	case KindClusterManagerProvider:
-		return NewKindClusterManager()
+		return NewKindClusterManager(c.ContainerRuntime, c.IPFamily)
+	case MinikubeClusterManagerProvider:
+		return NewMinikubeClusterManager()
+	case K3dClusterManagerProvider:
+		return NewK3dClusterManager()
 	case NoneClusterManagerProvider:
 		return NewNoopClusterManager()
 	}
 
-	// By default, return a noop cluster manager in cases we can't parse what provider the users gave
-	return NewNoopClusterManager()
+	// Other providers are implemented by a binary on the PATH. Clusters whose provider cannot be
+	// found can still be listed, but not changed.
+	if path, err := lookupExternalProvider(c.Provider); err == nil {
+		return NewExternalClusterManager(c.Provider, path)
+	}
+	return MissingExternalClusterManager{Name: c.Provider}
+}
+
+// ValidateProvider returns an error if the provider is neither built in nor implemented by a
+// binary on the PATH.
+func ValidateProvider(name string) error {
+	for _, p := range providers {
+		if name == p {
+			return nil
+		}
+	}
+	if _, err := lookupExternalProvider(name); err == nil {
+		return nil
+	}
+	return fmt.Errorf("unknown provider %q, expected one of: %s, or a provider implemented by %s%s on the PATH",
+		name, strings.Join(providers, ", "), ExternalProviderPrefix, name)
 }
 
 // NewNoopClusterManager creates a new noop cluster manager - intended for use with "none" provider
@@ -64,8 +177,44 @@ func NewNoopClusterManager() Manager {
 	return NoopClusterManager{}
 }
 
-// NewKindClusterManager gets a ClusterManager implementation for the kind provider.
-func NewKindClusterManager() Manager {
-	// For now, just hard coding to return our KindClusterManager.
-	return KindClusterManager{}
+// NewMinikubeClusterManager gets a ClusterManager implementation for the minikube provider.
+func NewMinikubeClusterManager() Manager {
+	return MinikubeClusterManager{}
+}
+
+// NewK3dClusterManager gets a ClusterManager implementation for the k3d provider.
+func NewK3dClusterManager() Manager {
+	return K3dClusterManager{}
+}
+
+// NewExternalClusterManager gets a ClusterManager implementation for a provider implemented by
+// the binary at path.
+func NewExternalClusterManager(name, path string) Manager {
+	return ExternalClusterManager{Name: name, Path: path}
+}
+
+// NewKindClusterManager gets a ClusterManager implementation for the kind provider, creating nodes
+// with the named container runtime and networking of the IP family.
+func NewKindClusterManager(runtime, ipFamily string) Manager {
+	return KindClusterManager{runtime: NewContainerRuntime(runtime), ipFamily: ipFamily}
+}
+
+// endpointFromKubeconfig returns the API server address of the current context in the kubeconfig.
+// An empty string is returned when it cannot be determined.
+func endpointFromKubeconfig(kcBytes []byte) string {
+	kc, err := clientcmd.Load(kcBytes)
+	if err != nil {
+		return ""
+	}
+
+	kubeContext, ok := kc.Contexts[kc.CurrentContext]
+	if !ok {
+		return ""
+	}
+	kubeCluster, ok := kc.Clusters[kubeContext.Cluster]
+	if !ok {
+		return ""
+	}
+
+	return kubeCluster.Server
 }
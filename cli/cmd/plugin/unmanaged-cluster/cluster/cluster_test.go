@@ -0,0 +1,20 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"testing"
+)
+
+func TestValidateProvider(t *testing.T) {
+	for _, p := range []string{"kind", "minikube", "k3d", "none"} {
+		if err := ValidateProvider(p); err != nil {
+			t.Errorf("expected provider %s to be valid. Error: %s", p, err)
+		}
+	}
+
+	if err := ValidateProvider("kinda"); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+}
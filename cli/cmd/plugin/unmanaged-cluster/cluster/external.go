@@ -0,0 +1,327 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+const (
+	// ExternalProviderPrefix is the prefix of the binaries, found on the PATH, that implement
+	// providers outside of the unmanaged-cluster plugin. The provider "foo" is implemented by
+	// tanzu-unmanaged-provider-foo.
+	ExternalProviderPrefix = "tanzu-unmanaged-provider-"
+	// ExternalProviderAPIVersion is the version of the protocol spoken with external providers.
+	ExternalProviderAPIVersion = "v1alpha1"
+)
+
+// Operations requested from external providers.
+const (
+	externalCreate    = "create"
+	externalGet       = "get"
+	externalDelete    = "delete"
+	externalStop      = "stop"
+	externalStart     = "start"
+	externalPrepare   = "prepare"
+	externalPreflight = "preflight"
+	externalNotify    = "notify"
+)
+
+// ExternalClusterManager is a ClusterManager implementation that delegates to a provider binary.
+// Each operation runs the binary once, with an ExternalProviderRequest written to its stdin. The
+// binary writes an ExternalProviderResponse to its stdout and exits with a non-zero code when the
+// operation fails.
+type ExternalClusterManager struct {
+	// Name is the name of the provider.
+	Name string
+	// Path is the path of the provider's binary.
+	Path string
+}
+
+// MissingExternalClusterManager is used for clusters whose external provider is not found on the
+// PATH. Operations that need the provider fail, so a cluster is never reported as deleted, stopped or
+// started while it is left unchanged. Otherwise, it behaves as NoopClusterManager.
+type MissingExternalClusterManager struct {
+	NoopClusterManager
+	// Name is the name of the provider.
+	Name string
+}
+
+// ExternalProviderRequest is the request written to the stdin of an external provider.
+type ExternalProviderRequest struct {
+	// APIVersion is the version of the protocol.
+	APIVersion string `json:"apiVersion"`
+	// Operation is one of create, get, delete, stop, start, prepare, preflight or notify.
+	Operation string `json:"operation"`
+	// Config is the cluster's configuration, keyed as in the configuration file. It is not set
+	// for the preflight and notify operations.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// ExternalProviderResponse is the response an external provider writes to its stdout.
+type ExternalProviderResponse struct {
+	// Cluster is the cluster that was created or retrieved, for the create and get operations.
+	Cluster *ExternalCluster `json:"cluster,omitempty"`
+	// Warnings are non-blocking issues found by the preflight operation.
+	Warnings []string `json:"warnings,omitempty"`
+	// Errors are blocking issues found by the preflight operation.
+	Errors []string `json:"errors,omitempty"`
+	// Messages are the lines displayed for the notify operation.
+	Messages []string `json:"messages,omitempty"`
+	// Error describes why the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ExternalCluster is a cluster as returned by an external provider.
+type ExternalCluster struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+	// Kubeconfig is the kubeconfig of the cluster, base64 encoded. It is written to the
+	// cluster's KubeconfigPath when the cluster is created.
+	Kubeconfig []byte `json:"kubeconfig,omitempty"`
+	// Status is one of Running, Stopped, Missing or Unknown.
+	Status string `json:"status,omitempty"`
+	// Endpoint is the address of the cluster's API server. It is read from the kubeconfig when
+	// not set.
+	Endpoint string `json:"endpoint,omitempty"`
+	// NodeImage is the image the cluster's nodes run, if any.
+	NodeImage string `json:"nodeImage,omitempty"`
+	// Nodes are the nodes that make up the cluster.
+	Nodes []Node `json:"nodes,omitempty"`
+	// ContextName is the name of the cluster's context in its kubeconfig. The current context of
+	// the kubeconfig is used when not set.
+	ContextName string `json:"contextName,omitempty"`
+}
+
+// Create will create a new cluster with the external provider, writing its kubeconfig to the
+// cluster's KubeconfigPath.
+func (ecm ExternalClusterManager) Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	resp, err := ecm.run(externalCreate, c)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Cluster == nil || len(resp.Cluster.Kubeconfig) == 0 {
+		return nil, fmt.Errorf("provider %s did not return a kubeconfig for cluster %s", ecm.Name, c.ClusterName)
+	}
+
+	err = os.WriteFile(c.KubeconfigPath, resp.Cluster.Kubeconfig, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Cluster.toKubernetesCluster(c), nil
+}
+
+// Get retrieves cluster information from the external provider.
+func (ecm ExternalClusterManager) Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	resp, err := ecm.run(externalGet, c)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Cluster == nil {
+		return &KubernetesCluster{
+			Name:   c.ClusterName,
+			Status: StatusMissing,
+		}, nil
+	}
+
+	kc := resp.Cluster.toKubernetesCluster(c)
+	if len(kc.Kubeconfig) == 0 {
+		kc.Kubeconfig, _ = os.ReadFile(c.KubeconfigPath)
+		if kc.Endpoint == "" {
+			kc.Endpoint = endpointFromKubeconfig(kc.Kubeconfig)
+		}
+	}
+	return kc, nil
+}
+
+// Delete removes a cluster with the external provider.
+func (ecm ExternalClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
+	_, err := ecm.run(externalDelete, c)
+	return err
+}
+
+// Stop stops the nodes of a cluster with the external provider.
+func (ecm ExternalClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	_, err := ecm.run(externalStop, c)
+	return err
+}
+
+// Start starts the nodes of a stopped cluster with the external provider.
+func (ecm ExternalClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	_, err := ecm.run(externalStart, c)
+	return err
+}
+
+// Prepare performs any steps the external provider can do prior to creating the cluster.
+func (ecm ExternalClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
+	_, err := ecm.run(externalPrepare, c)
+	return err
+}
+
+// PreflightCheck asks the external provider for issues that would cause problems for cluster
+// creation.
+func (ecm ExternalClusterManager) PreflightCheck() ([]string, []error) {
+	resp, err := ecm.run(externalPreflight, nil)
+	if err != nil {
+		return []string{}, []error{err}
+	}
+
+	var errs []error
+	for _, e := range resp.Errors {
+		errs = append(errs, fmt.Errorf("%s", e))
+	}
+	return resp.Warnings, errs
+}
+
+// ProviderNotify returns the external provider's notification used during cluster bootstrapping.
+func (ecm ExternalClusterManager) ProviderNotify() []string {
+	resp, err := ecm.run(externalNotify, nil)
+	if err != nil || len(resp.Messages) == 0 {
+		return []string{fmt.Sprintf("Cluster creation using %s provider %s", ecm.Name, ecm.Path)}
+	}
+	return resp.Messages
+}
+
+// Create fails, as the provider is not available.
+func (mcm MissingExternalClusterManager) Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	return nil, mcm.notFound(externalCreate, c)
+}
+
+// Delete fails, as the provider is not available.
+func (mcm MissingExternalClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
+	return mcm.notFound(externalDelete, c)
+}
+
+// Stop fails, as the provider is not available.
+func (mcm MissingExternalClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	return mcm.notFound(externalStop, c)
+}
+
+// Start fails, as the provider is not available.
+func (mcm MissingExternalClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	return mcm.notFound(externalStart, c)
+}
+
+// Prepare fails, as the provider is not available.
+func (mcm MissingExternalClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
+	return mcm.notFound(externalPrepare, c)
+}
+
+// notFound returns the error for an operation that cannot be run without the provider.
+func (mcm MissingExternalClusterManager) notFound(operation string, c *config.UnmanagedClusterConfig) error {
+	return fmt.Errorf("unable to %s cluster %s, provider %s was not found. Install %s%s on the PATH and retry",
+		operation, c.ClusterName, mcm.Name, ExternalProviderPrefix, mcm.Name)
+}
+
+// run runs the provider's binary for an operation and returns its response. An error is returned
+// when the binary fails or its response is invalid.
+func (ecm ExternalClusterManager) run(operation string, c *config.UnmanagedClusterConfig) (*ExternalProviderResponse, error) {
+	req, err := newExternalProviderRequest(operation, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(ecm.Path)
+	cmd.SetStdin(bytes.NewReader(req))
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	runErr := cmd.Run()
+
+	resp, err := parseExternalProviderResponse(stdout.Bytes())
+	if err != nil && runErr == nil {
+		return nil, fmt.Errorf("provider %s returned an invalid response to %s. Error: %s", ecm.Name, operation, err.Error())
+	}
+	if runErr != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if resp != nil && resp.Error != "" {
+			msg = resp.Error
+		}
+		if msg == "" {
+			msg = runErr.Error()
+		}
+		return nil, fmt.Errorf("provider %s failed to %s. Error: %s", ecm.Name, operation, msg)
+	}
+	return resp, nil
+}
+
+// newExternalProviderRequest renders the request for an operation. The configuration is keyed as
+// in the configuration file, so providers see the same names users do.
+func newExternalProviderRequest(operation string, c *config.UnmanagedClusterConfig) ([]byte, error) {
+	req := ExternalProviderRequest{
+		APIVersion: ExternalProviderAPIVersion,
+		Operation:  operation,
+	}
+
+	if c != nil {
+		rawConfig, err := yaml.Marshal(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render configuration for provider. Error: %s", err.Error())
+		}
+		err = yaml.Unmarshal(rawConfig, &req.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render configuration for provider. Error: %s", err.Error())
+		}
+	}
+
+	return json.Marshal(req)
+}
+
+// parseExternalProviderResponse parses the response of an external provider. Providers may write
+// nothing for operations that return no data.
+func parseExternalProviderResponse(output []byte) (*ExternalProviderResponse, error) {
+	resp := &ExternalProviderResponse{}
+	if len(bytes.TrimSpace(output)) == 0 {
+		return resp, nil
+	}
+
+	err := json.Unmarshal(output, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// toKubernetesCluster converts a cluster returned by an external provider.
+func (ec *ExternalCluster) toKubernetesCluster(c *config.UnmanagedClusterConfig) *KubernetesCluster {
+	kc := &KubernetesCluster{
+		Name:        ec.Name,
+		Kubeconfig:  ec.Kubeconfig,
+		Status:      ec.Status,
+		Endpoint:    ec.Endpoint,
+		NodeImage:   ec.NodeImage,
+		Nodes:       ec.Nodes,
+		ContextName: ec.ContextName,
+	}
+	if kc.Name == "" {
+		kc.Name = c.ClusterName
+	}
+	if kc.Status == "" {
+		kc.Status = StatusUnknown
+	}
+	if kc.Endpoint == "" {
+		kc.Endpoint = endpointFromKubeconfig(kc.Kubeconfig)
+	}
+	kc.setCreatedAt()
+	return kc
+}
+
+// lookupExternalProvider returns the path of the binary implementing a provider. An error is
+// returned when it is not found on the PATH.
+func lookupExternalProvider(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid provider name %q", name)
+	}
+	return osexec.LookPath(ExternalProviderPrefix + name)
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+// fakeProvider answers get with a running cluster, fails delete and returns nothing otherwise.
+const fakeProvider = `#!/bin/sh
+request=$(cat)
+case "$request" in
+  *'"operation":"get"'*)
+    echo '{"cluster":{"name":"test","kubeconfig":"a3ViZWNvbmZpZw==","status":"Running","endpoint":"https://127.0.0.1:6443",
+      "contextName":"fake-test","nodes":[{"name":"test-cp","role":"control-plane","status":"running","createdAt":"2022-04-01T10:00:00Z"}]}}' ;;
+  *'"operation":"delete"'*)
+    echo '{"error":"cluster is busy"}'
+    exit 1 ;;
+esac
+`
+
+func installFakeProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake provider is a shell script")
+	}
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, ExternalProviderPrefix+"fake"), []byte(fakeProvider), 0755) //nolint:gosec
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestNewExternalProviderRequest(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName: "test",
+		HTTPProxy:   "http://proxy:3128",
+		ProviderConfiguration: map[string]interface{}{
+			"vmSize": "large",
+		},
+	}
+
+	data, err := newExternalProviderRequest("create", c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req := ExternalProviderRequest{}
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		t.Fatalf("request is not valid JSON: %s", err)
+	}
+	if req.APIVersion != ExternalProviderAPIVersion || req.Operation != "create" {
+		t.Errorf("unexpected request %s", string(data))
+	}
+	if req.Config["ClusterName"] != "test" || req.Config["HttpProxy"] != "http://proxy:3128" {
+		t.Errorf("expected configuration to be keyed as in the configuration file: %s", string(data))
+	}
+	providerConfig, ok := req.Config["ProviderConfiguration"].(map[string]interface{})
+	if !ok || providerConfig["vmSize"] != "large" {
+		t.Errorf("expected ProviderConfiguration to be passed through: %s", string(data))
+	}
+
+	data, err = newExternalProviderRequest("notify", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != `{"apiVersion":"v1alpha1","operation":"notify"}` {
+		t.Errorf("unexpected request %s", string(data))
+	}
+}
+
+func TestExternalClusterManager(t *testing.T) {
+	installFakeProvider(t)
+
+	if err := ValidateProvider("fake"); err != nil {
+		t.Fatalf("expected provider on the PATH to be valid. Error: %s", err)
+	}
+	if err := ValidateProvider("../fake"); err == nil {
+		t.Error("expected an error for a provider name containing a path")
+	}
+
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:    "test",
+		Provider:       "fake",
+		KubeconfigPath: filepath.Join(t.TempDir(), "kube.conf"),
+	}
+	mgr := NewClusterManager(c)
+	if _, ok := mgr.(ExternalClusterManager); !ok {
+		t.Fatalf("expected an external cluster manager, got %T", mgr)
+	}
+
+	kc, err := mgr.Get(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if kc.Status != StatusRunning || string(kc.Kubeconfig) != "kubeconfig" || kc.Endpoint != "https://127.0.0.1:6443" ||
+		kc.ContextName != "fake-test" {
+		t.Errorf("unexpected cluster %v", kc)
+	}
+	if cp, workers := kc.NodeCounts(); cp != 1 || workers != 0 || kc.CreatedAt.IsZero() {
+		t.Errorf("unexpected nodes %v", kc.Nodes)
+	}
+
+	err = mgr.Delete(c)
+	if err == nil || err.Error() != "provider fake failed to delete. Error: cluster is busy" {
+		t.Errorf("expected the provider's error, got: %v", err)
+	}
+
+	_, err = mgr.Create(c)
+	if err == nil {
+		t.Error("expected an error when no kubeconfig is returned")
+	}
+
+	warnings, errs := mgr.PreflightCheck()
+	if len(warnings) != 0 || len(errs) != 0 {
+		t.Errorf("unexpected preflight results %v %v", warnings, errs)
+	}
+}
+
+func TestMissingExternalClusterManager(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:    "test",
+		Provider:       "missing",
+		KubeconfigPath: filepath.Join(t.TempDir(), "kube.conf"),
+	}
+	mgr := NewClusterManager(c)
+	if _, ok := mgr.(MissingExternalClusterManager); !ok {
+		t.Fatalf("expected a missing external cluster manager, got %T", mgr)
+	}
+
+	if err := mgr.Delete(c); err == nil {
+		t.Error("expected delete to fail without the provider")
+	}
+	if err := mgr.Stop(c); err == nil {
+		t.Error("expected stop to fail without the provider")
+	}
+	if err := mgr.Start(c); err == nil {
+		t.Error("expected start to fail without the provider")
+	}
+
+	kc, err := mgr.Get(c)
+	if err != nil || kc.Status != StatusMissing {
+		t.Errorf("expected the cluster to still be listed, got %v, error: %v", kc, err)
+	}
+}
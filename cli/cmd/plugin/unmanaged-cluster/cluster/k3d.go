@@ -0,0 +1,356 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+const (
+	// k3sImageRepository is the repository of the k3s images clusters are created from.
+	k3sImageRepository = "docker.io/rancher/k3s"
+	// k3sImageKey is the ProviderConfiguration key to run a specific k3s image.
+	k3sImageKey = "k3sImage"
+
+	k3dServerRole = "server"
+	k3dAgentRole  = "agent"
+)
+
+// K3dClusterManager is a ClusterManager implementation for working with k3d clusters, which run
+// k3s in docker containers.
+type K3dClusterManager struct {
+}
+
+// k3dCluster is a cluster as listed by k3d.
+type k3dCluster struct {
+	Name  string    `json:"name"`
+	Nodes []k3dNode `json:"nodes"`
+}
+
+// k3dNode is a node of a k3d cluster. Besides servers and agents, k3d runs nodes such as a load
+// balancer in front of the servers.
+type k3dNode struct {
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+	Image   string `json:"image"`
+	Created string `json:"created"`
+	State   struct {
+		Running bool   `json:"Running"`
+		Status  string `json:"Status"`
+	} `json:"State"`
+}
+
+// Create will create a new k3d cluster or return an error.
+func (k3m K3dClusterManager) Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	args, err := k3dCreateArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("k3d", args...)
+	_, err = exec.Output(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("k3d returned error: %s", err)
+	}
+
+	// k3d does not write a kubeconfig for the cluster on its own
+	cmd = exec.Command("k3d", "kubeconfig", "get", c.ClusterName)
+	kcBytes, err := exec.Output(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get kubeconfig of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	err = os.WriteFile(c.KubeconfigPath, kcBytes, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	kc := &KubernetesCluster{
+		Name:        c.ClusterName,
+		Kubeconfig:  kcBytes,
+		ContextName: "k3d-" + c.ClusterName,
+	}
+
+	nodeNames, _ := listK3dNodeNames(c.ClusterName)
+	for _, nodeName := range nodeNames {
+		// TODO(stmcginnis): As with kind, failing to patch a node for antrea is not reported.
+		if strings.Contains(c.Cni, "antrea") {
+			_ = patchForAntrea(dockerRuntime, nodeName)
+		}
+		// As with kind, a cluster whose nodes cannot run calico is deleted rather than returned
+		if strings.Contains(c.Cni, "calico") {
+			if err := patchForCalico(dockerRuntime, nodeName); err != nil {
+				_ = k3m.Delete(c)
+				return nil, err
+			}
+		}
+	}
+
+	return kc, nil
+}
+
+// Get retrieves the nodes, their state and the kubeconfig of a k3d cluster.
+func (k3m K3dClusterManager) Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	kc := &KubernetesCluster{
+		Name:   c.ClusterName,
+		Status: StatusMissing,
+	}
+
+	nodes, err := listK3dNodes(c.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return kc, nil
+	}
+
+	kc.Status = StatusRunning
+	for _, n := range nodes {
+		if !n.State.Running {
+			kc.Status = StatusStopped
+		}
+		kc.NodeImage = n.Image
+		kc.Nodes = append(kc.Nodes, n.toNode())
+	}
+	kc.setCreatedAt()
+
+	kc.Kubeconfig, _ = os.ReadFile(c.KubeconfigPath)
+	kc.Endpoint = endpointFromKubeconfig(kc.Kubeconfig)
+
+	return kc, nil
+}
+
+// Delete removes a k3d cluster.
+func (k3m K3dClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
+	cmd := exec.Command("k3d", "cluster", "delete", c.ClusterName)
+	_, err := exec.Output(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to delete cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	return nil
+}
+
+// Stop stops the nodes of a k3d cluster.
+func (k3m K3dClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	cmd := exec.Command("k3d", "cluster", "stop", c.ClusterName)
+	_, err := exec.Output(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to stop nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	return nil
+}
+
+// Start starts the nodes of a stopped k3d cluster.
+func (k3m K3dClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	cmd := exec.Command("k3d", "cluster", "start", c.ClusterName)
+	_, err := exec.Output(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to start nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+
+	// As with kind, the network settings patched for the CNI are reset when a node restarts
+	nodeNames, _ := listK3dNodeNames(c.ClusterName)
+	for _, nodeName := range nodeNames {
+		if strings.Contains(c.Cni, "antrea") {
+			_ = patchForAntrea(dockerRuntime, nodeName)
+		}
+		if strings.Contains(c.Cni, "calico") {
+			if err := patchForCalico(dockerRuntime, nodeName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Prepare will fetch the k3s image to the cluster host.
+func (k3m K3dClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
+	image, err := k3sImage(c)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("docker", "pull", image)
+	_, err = exec.Output(cmd)
+	return err
+}
+
+// PreflightCheck performs any pre-checks that can find issues up front that
+// would cause problems for cluster creation. k3s runs with far less memory than
+// kind, so docker's resources are not checked.
+func (k3m K3dClusterManager) PreflightCheck() ([]string, []error) {
+	cmd := exec.Command("k3d", "version")
+	if err := cmd.Run(); err != nil {
+		return []string{}, []error{fmt.Errorf("k3d is not installed or not on the PATH. Error when attempting to run k3d version: %w", err)}
+	}
+
+	cmd = exec.Command("docker", "ps")
+	if err := cmd.Run(); err != nil {
+		return []string{}, []error{fmt.Errorf("docker is not installed or not reachable. Verify it's installed, running, and your user has permissions to interact with it. Error when attempting to run docker ps: %w", err)}
+	}
+
+	return nil, nil
+}
+
+// ProviderNotify returns the k3d provider notification used during cluster bootstrapping
+func (k3m K3dClusterManager) ProviderNotify() []string {
+	return []string{
+		"Cluster creation using k3d!",
+		"❤️  Checkout this awesome project at https://k3d.io",
+	}
+}
+
+// k3dCreateArgs returns the arguments to create a k3d cluster from the configuration.
+func k3dCreateArgs(c *config.UnmanagedClusterConfig) ([]string, error) {
+	cpnc, err := strconv.Atoi(c.ControlPlaneNodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if cpnc < 1 {
+		return nil, fmt.Errorf("cannot have less than 1 control plane node")
+	}
+	wnc, err := strconv.Atoi(c.WorkerNodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if wnc < 0 {
+		return nil, fmt.Errorf("cannot have less than 0 worker nodes")
+	}
+	image, err := k3sImage(c)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"cluster", "create", c.ClusterName,
+		"--servers", strconv.Itoa(cpnc),
+		"--agents", strconv.Itoa(wnc),
+		"--image", image,
+		"--kubeconfig-update-default=false",
+		"--kubeconfig-switch-context=false",
+		"--wait",
+	}
+
+	// The CNI and ingress are installed from the TKR rather than by k3s
+	k3sArgs := []string{"--flannel-backend=none", "--disable-network-policy", "--disable=traefik"}
+	if c.PodCidr != "" {
+		k3sArgs = append(k3sArgs, "--cluster-cidr="+c.PodCidr)
+	}
+	if c.ServiceCidr != "" {
+		k3sArgs = append(k3sArgs, "--service-cidr="+c.ServiceCidr)
+	}
+	for _, k3sArg := range k3sArgs {
+		args = append(args, "--k3s-arg", k3sArg+"@server:*")
+	}
+
+	// Ports are forwarded through the load balancer k3d runs in front of the servers
+	for _, portToForward := range c.PortsToForward {
+		port := strconv.Itoa(portToForward.ContainerPort)
+		if portToForward.HostPort != 0 {
+			port = fmt.Sprintf("%d:%s", portToForward.HostPort, port)
+		}
+		if portToForward.Protocol != "" {
+			port = fmt.Sprintf("%s/%s", port, portToForward.Protocol)
+		}
+		args = append(args, "--port", port+"@loadbalancer")
+	}
+
+	return args, nil
+}
+
+// k3sImage returns the k3s image the cluster's nodes run. Unless set in the ProviderConfiguration,
+// it is the k3s release of the Kubernetes version of the TKR's node image.
+func k3sImage(c *config.UnmanagedClusterConfig) (string, error) {
+	if image, ok := c.ProviderConfiguration[k3sImageKey]; ok {
+		if _, ok := image.(string); !ok {
+			return "", fmt.Errorf("ProviderConfiguration.%s wrong type, expected string", k3sImageKey)
+		}
+		return image.(string), nil
+	}
+
+	match := nodeImageVersion.FindStringSubmatch(c.NodeImage)
+	if match == nil {
+		return "", fmt.Errorf("unable to determine the Kubernetes version of node image %s, set ProviderConfiguration.%s", c.NodeImage, k3sImageKey)
+	}
+	return fmt.Sprintf("%s:%s-k3s1", k3sImageRepository, match[1]), nil
+}
+
+// listK3dNodes returns the server and agent nodes of a k3d cluster. No nodes are returned when
+// the cluster does not exist.
+func listK3dNodes(clusterName string) ([]k3dNode, error) {
+	cmd := exec.Command("k3d", "cluster", "list", clusterName, "--output", "json")
+	output, err := exec.Output(cmd)
+	if err != nil {
+		// k3d fails to list clusters that do not exist
+		var runErr *exec.RunError
+		if errors.As(err, &runErr) && isK3dClusterNotFound(runErr.Output) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list nodes of cluster %s. Error: %s", clusterName, err.Error())
+	}
+	return parseK3dClusterList(output)
+}
+
+// isK3dClusterNotFound returns whether the output of a failed k3d command reports the cluster does
+// not exist.
+func isK3dClusterNotFound(output []byte) bool {
+	return strings.Contains(string(output), "not found") || strings.Contains(string(output), "No nodes found")
+}
+
+// listK3dNodeNames returns the names of the server and agent containers of a k3d cluster.
+func listK3dNodeNames(clusterName string) ([]string, error) {
+	nodes, err := listK3dNodes(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeNames := []string{}
+	for _, n := range nodes {
+		nodeNames = append(nodeNames, n.Name)
+	}
+	return nodeNames, nil
+}
+
+// parseK3dClusterList parses the clusters listed by k3d, returning the server and agent nodes.
+func parseK3dClusterList(output []byte) ([]k3dNode, error) {
+	clusters := []k3dCluster{}
+	err := json.Unmarshal(output, &clusters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse k3d cluster list. Error: %s", err.Error())
+	}
+
+	nodes := []k3dNode{}
+	for _, cl := range clusters {
+		for _, n := range cl.Nodes {
+			if n.Role == k3dServerRole || n.Role == k3dAgentRole {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// toNode converts a k3d node to a cluster node.
+func (n *k3dNode) toNode() Node {
+	node := Node{
+		Name:   n.Name,
+		Role:   WorkerRole,
+		Image:  n.Image,
+		Status: n.State.Status,
+	}
+	if n.Role == k3dServerRole {
+		node.Role = ControlPlaneRole
+	}
+	node.CreatedAt, _ = time.Parse(time.RFC3339, n.Created)
+	return node
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+func TestK3dCreateArgs(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		NodeImage:             "projects.registry.vmware.com/tce/kind/node:v1.22.5",
+		ControlPlaneNodeCount: "1",
+		WorkerNodeCount:       "2",
+		PodCidr:               "10.244.0.0/16",
+		ServiceCidr:           "10.96.0.0/16",
+		PortsToForward: []config.PortMap{
+			{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+			{ContainerPort: 443},
+		},
+	}
+
+	args, err := k3dCreateArgs(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "cluster create test --servers 1 --agents 2 --image docker.io/rancher/k3s:v1.22.5-k3s1 " +
+		"--kubeconfig-update-default=false --kubeconfig-switch-context=false --wait " +
+		"--k3s-arg --flannel-backend=none@server:* --k3s-arg --disable-network-policy@server:* " +
+		"--k3s-arg --disable=traefik@server:* --k3s-arg --cluster-cidr=10.244.0.0/16@server:* " +
+		"--k3s-arg --service-cidr=10.96.0.0/16@server:* --port 8080:80/tcp@loadbalancer --port 443@loadbalancer"
+	if joined := strings.Join(args, " "); joined != expected {
+		t.Errorf("unexpected arguments: %s", joined)
+	}
+}
+
+func TestK3sImage(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		NodeImage: "projects.registry.vmware.com/tce/kind/node:v1.22.5",
+		ProviderConfiguration: map[string]interface{}{
+			"k3sImage": "docker.io/rancher/k3s:v1.22.7-k3s1",
+		},
+	}
+
+	image, err := k3sImage(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if image != "docker.io/rancher/k3s:v1.22.7-k3s1" {
+		t.Errorf("expected the configured k3s image, was: %s", image)
+	}
+
+	c.ProviderConfiguration = nil
+	c.NodeImage = "projects.registry.vmware.com/tce/kind/node"
+	_, err = k3sImage(c)
+	if err == nil {
+		t.Error("expected an error for a node image without a version")
+	}
+}
+
+func TestParseK3dClusterList(t *testing.T) {
+	output := `[{"name":"test","nodes":[
+		{"name":"k3d-test-server-0","role":"server","image":"docker.io/rancher/k3s:v1.22.5-k3s1",
+			"created":"2022-04-01T10:00:00Z","State":{"Running":true,"Status":"running"}},
+		{"name":"k3d-test-agent-0","role":"agent","image":"docker.io/rancher/k3s:v1.22.5-k3s1",
+			"created":"2022-04-01T10:00:01Z","State":{"Running":false,"Status":"exited"}},
+		{"name":"k3d-test-serverlb","role":"loadbalancer","image":"ghcr.io/k3d-io/k3d-proxy:5.4.1",
+			"created":"2022-04-01T10:00:02Z","State":{"Running":true,"Status":"running"}}]}]`
+
+	nodes, err := parseK3dClusterList([]byte(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes but %d returned", len(nodes))
+	}
+
+	server := nodes[0].toNode()
+	if server.Role != ControlPlaneRole || server.Status != "running" || server.CreatedAt.IsZero() {
+		t.Errorf("unexpected server node %v", server)
+	}
+	agent := nodes[1].toNode()
+	if agent.Role != WorkerRole || nodes[1].State.Running {
+		t.Errorf("unexpected agent node %v", agent)
+	}
+}
+
+func TestIsK3dClusterNotFound(t *testing.T) {
+	notFound := `FATA[0000] failed to get cluster 'test': No nodes found for given cluster`
+	if !isK3dClusterNotFound([]byte(notFound)) {
+		t.Errorf("expected %q to report a missing cluster", notFound)
+	}
+
+	dockerDown := `FATA[0000] Failed to get clusters: Cannot connect to the Docker daemon at unix:///var/run/docker.sock`
+	if isK3dClusterNotFound([]byte(dockerDown)) {
+		t.Errorf("expected %q not to report a missing cluster", dockerDown)
+	}
+}
@@ -6,13 +6,14 @@ package cluster
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	kindconfig "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
@@ -20,12 +21,14 @@ import (
 	"sigs.k8s.io/kind/pkg/exec"
 
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/registry"
 )
 
 const (
 	minMemoryBytes     = 2147483648
 	minCPUCount        = 1
 	kindConfigFileName = "kindconfig.yaml"
+	maxPort            = 65535
 )
 
 // TODO(stmcginnis): Keeping this here for now for reference, remove once we're
@@ -50,13 +53,17 @@ const (
 // KindClusterManager is a ClusterManager implementation for working with
 // Kind clusters.
 type KindClusterManager struct {
+	// runtime runs the cluster's node containers.
+	runtime ContainerRuntime
+	// ipFamily is the IP family of the cluster's networking.
+	ipFamily string
 }
 
 // Create will create a new kind cluster or return an error.
 func (kcm KindClusterManager) Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
 	var err error
 
-	kindProvider := kindcluster.NewProvider()
+	kindProvider := kcm.runtime.kindProvider()
 	clusterConfig := kindcluster.CreateWithKubeconfigPath(c.KubeconfigPath)
 
 	// Serlize unstructured data into a kindProviderConfig.
@@ -92,6 +99,16 @@ func (kcm KindClusterManager) Create(c *config.UnmanagedClusterConfig) (*Kuberne
 		return nil, fmt.Errorf("kind returned error: %s", err)
 	}
 
+	// The nodes can only reach the local registry once it is attached to their network. A cluster
+	// that cannot pull through it is deleted rather than returned unusable.
+	if c.LocalRegistry != "" {
+		err = registry.Connect(kcm.runtime, c.ClusterName, kcm.runtime.kindNetworkName())
+		if err != nil {
+			_ = kindProvider.Delete(c.ClusterName, c.KubeconfigPath)
+			return nil, err
+		}
+	}
+
 	// readkubeconfig in bytes
 	kcBytes, err := os.ReadFile(c.KubeconfigPath)
 	if err != nil {
@@ -99,14 +116,15 @@ func (kcm KindClusterManager) Create(c *config.UnmanagedClusterConfig) (*Kuberne
 	}
 
 	kc := &KubernetesCluster{
-		Name:       c.ClusterName,
-		Kubeconfig: kcBytes,
+		Name:        c.ClusterName,
+		Kubeconfig:  kcBytes,
+		ContextName: "kind-" + c.ClusterName,
 	}
 
 	if strings.Contains(c.Cni, "antrea") {
 		nodes, _ := kindProvider.ListNodes(c.ClusterName)
 		for _, n := range nodes {
-			if err := patchForAntrea(n.String()); err != nil { //nolint:staticcheck
+			if err := patchForAntrea(kcm.runtime, n.String()); err != nil { //nolint:staticcheck
 				// TODO(stmcginnis): We probably don't want to just error out
 				// since the cluster has already been created, but we should
 				// at least report a warning back to the user that part of the
@@ -115,6 +133,18 @@ func (kcm KindClusterManager) Create(c *config.UnmanagedClusterConfig) (*Kuberne
 		}
 	}
 
+	if strings.Contains(c.Cni, "calico") {
+		nodes, _ := kindProvider.ListNodes(c.ClusterName)
+		for _, n := range nodes {
+			// Unlike antrea, calico-node never becomes ready on a node that is not patched, so the
+			// cluster is deleted rather than returned unusable
+			if err := patchForCalico(kcm.runtime, n.String()); err != nil {
+				_ = kindProvider.Delete(c.ClusterName, c.KubeconfigPath)
+				return nil, err
+			}
+		}
+	}
+
 	return kc, nil
 }
 
@@ -144,7 +174,7 @@ func kindConfigFromClusterConfig(c *config.UnmanagedClusterConfig) ([]byte, erro
 	kindConfig.Kind = "Cluster"
 	kindConfig.APIVersion = "kind.x-k8s.io/v1alpha4"
 	kindConfig.Name = c.ClusterName
-	nodes, err := setNumberOfNodes(c)
+	nodes, err := kindNodes(c)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +183,9 @@ func kindConfigFromClusterConfig(c *config.UnmanagedClusterConfig) ([]byte, erro
 
 	// Now populate or override with the specified configuration
 	kindConfig.Networking.DisableDefaultCNI = true
+	if c.IPFamily != "" {
+		kindConfig.Networking.IPFamily = kindconfig.ClusterIPFamily(c.IPFamily)
+	}
 	if c.PodCidr != "" {
 		kindConfig.Networking.PodSubnet = c.PodCidr
 	}
@@ -160,26 +193,35 @@ func kindConfigFromClusterConfig(c *config.UnmanagedClusterConfig) ([]byte, erro
 		kindConfig.Networking.ServiceSubnet = c.ServiceCidr
 	}
 
-	// Apply the node image to all nodes
+	// Customize the Kubernetes components
+	kindConfig.FeatureGates = c.FeatureGates
+	kindConfig.RuntimeConfig = c.RuntimeConfig
+	kindConfig.KubeadmConfigPatches, err = clusterKubeadmConfigPatches(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate kubeadm config patches. Error: %s", err.Error())
+	}
+
+	// Pull images through the local registry, if one was started alongside the cluster
+	if c.LocalRegistry != "" {
+		kindConfig.ContainerdConfigPatches = append(kindConfig.ContainerdConfigPatches,
+			registry.ContainerdConfigPatch(c.LocalRegistry, registry.ClusterAddress(c.ClusterName)))
+	}
+
+	// Configure registry mirrors and insecure registries
+	if patch := registryConfigPatch(c); patch != "" {
+		kindConfig.ContainerdConfigPatches = append(kindConfig.ContainerdConfigPatches, patch)
+	}
+
+	// Apply the node image and trusted certificate authorities to all nodes
 	for i := range kindConfig.Nodes {
 		kindConfig.Nodes[i].Image = c.NodeImage
+		kindConfig.Nodes[i].ExtraMounts = append(kindConfig.Nodes[i].ExtraMounts, caCertificateMounts(c)...)
 	}
 
 	// Do the port mapping for the first node (which should by default be the control plane)
-	// If users want a more granular way to apply port mappings, they should use the rawKindConfig
+	// If users want a more granular way to apply port mappings, they should customize the Nodes
 	for _, portToForward := range c.PortsToForward {
-		portMapping := kindconfig.PortMapping{}
-		if portToForward.ContainerPort != 0 {
-			portMapping.ContainerPort = int32(portToForward.ContainerPort)
-		}
-		if portToForward.HostPort != 0 {
-			portMapping.HostPort = int32(portToForward.HostPort)
-		}
-		if portToForward.Protocol != "" {
-			portMapping.Protocol = kindconfig.PortMappingProtocol(portToForward.Protocol)
-		}
-
-		kindConfig.Nodes[0].ExtraPortMappings = append(kindConfig.Nodes[0].ExtraPortMappings, portMapping)
+		kindConfig.Nodes[0].ExtraPortMappings = append(kindConfig.Nodes[0].ExtraPortMappings, kindPortMapping(portToForward))
 	}
 
 	// Marshal it into the raw bytes we need for creation
@@ -198,6 +240,39 @@ func kindConfigFromClusterConfig(c *config.UnmanagedClusterConfig) ([]byte, erro
 	return rawConfig.Bytes(), nil
 }
 
+// registryConfigPatch returns a containerd configuration patch for the registry mirrors and insecure
+// registries of the configuration. An empty patch is returned when neither is configured.
+func registryConfigPatch(c *config.UnmanagedClusterConfig) string {
+	var patch strings.Builder
+	for _, mirror := range c.RegistryMirrors {
+		endpoints := make([]string, len(mirror.Endpoints))
+		for i, endpoint := range mirror.Endpoints {
+			endpoints[i] = strconv.Quote(endpoint)
+		}
+		fmt.Fprintf(&patch, "[plugins.\"io.containerd.grpc.v1.cri\".registry.mirrors.%q]\n", mirror.Registry)
+		fmt.Fprintf(&patch, "  endpoint = [%s]\n", strings.Join(endpoints, ", "))
+	}
+	for _, host := range c.InsecureRegistries {
+		fmt.Fprintf(&patch, "[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.%q.tls]\n", host)
+		patch.WriteString("  insecure_skip_verify = true\n")
+	}
+	return patch.String()
+}
+
+// caCertificateMounts mounts the configured certificate authorities into a node's directory of
+// trusted certificates, which containerd loads in addition to the node's certificate bundle.
+func caCertificateMounts(c *config.UnmanagedClusterConfig) []kindconfig.Mount {
+	mounts := make([]kindconfig.Mount, 0, len(c.CACertificates))
+	for i, caPath := range c.CACertificates {
+		mounts = append(mounts, kindconfig.Mount{
+			HostPath:      caPath,
+			ContainerPath: fmt.Sprintf("/etc/ssl/certs/unmanaged-cluster-ca-%d.pem", i),
+			Readonly:      true,
+		})
+	}
+	return mounts
+}
+
 func writeKindConfigFile(configBytes []byte, clusterName string) error {
 	configDir, err := config.GetUnmanagedConfigPath()
 	if err != nil {
@@ -220,31 +295,15 @@ func setNumberOfNodes(c *config.UnmanagedClusterConfig) ([]kindconfig.Node, erro
 		return nil, err
 	}
 
-	if cpnc < 1 {
-		return nil, fmt.Errorf("cannot have less than 1 control plane node")
-	}
-
 	// Get and check worker count from config
 	wnc, err := strconv.Atoi(c.WorkerNodeCount)
 	if err != nil {
 		return nil, err
 	}
 
-	if wnc < 0 {
-		return nil, fmt.Errorf("cannot have less than 0 worker nodes")
-	}
-
-	// TODO (jpmcb): in single node clusters, control-plane nodes act as worker
-	// nodes, in which case the taint will be removed.
-	//
-	// But, in the case where there are no worker nodes but multiple control plane nodes,
-	// the taint is _not_ automatically removed so workloads will not be scheduled to the control plane nodes.
-	//
-	// In the future, the taint should be removed in order to support workloads being scheduled
-	// on control-plane nodes without worker nodes.
-	// https://kubernetes.io/docs/setup/independent/create-cluster-kubeadm/#control-plane-node-isolation
-	if cpnc > 1 && wnc == 0 {
-		return nil, fmt.Errorf("multiple control plane nodes require at least one worker node for workload placement")
+	err = validateNodeCounts(cpnc, wnc)
+	if err != nil {
+		return nil, err
 	}
 
 	nodes := []kindconfig.Node{}
@@ -268,101 +327,468 @@ func setNumberOfNodes(c *config.UnmanagedClusterConfig) ([]kindconfig.Node, erro
 	return nodes, nil
 }
 
-// Get retrieves cluster information or return an error indicating a problem.
-func (kcm KindClusterManager) Get(clusterName string) (*KubernetesCluster, error) {
-	return nil, nil
+// validateNodeCounts returns an error if a cluster cannot be created with the number of
+// control plane and worker nodes.
+func validateNodeCounts(cpnc, wnc int) error {
+	if cpnc < 1 {
+		return fmt.Errorf("cannot have less than 1 control plane node")
+	}
+
+	if wnc < 0 {
+		return fmt.Errorf("cannot have less than 0 worker nodes")
+	}
+
+	return nil
+}
+
+// kindNodes returns the nodes of a kind cluster. Nodes customized in the configuration are used
+// when set, otherwise identical nodes are created from the node counts.
+func kindNodes(c *config.UnmanagedClusterConfig) ([]kindconfig.Node, error) {
+	if len(c.Nodes) == 0 {
+		return setNumberOfNodes(c)
+	}
+
+	if err := ValidateNodes(c.Nodes); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]kindconfig.Node, 0, len(c.Nodes))
+	for i := range c.Nodes {
+		n, err := kindNodeFromConfig(&c.Nodes[i])
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// kindNodeFromConfig converts a node customized in the configuration to a kind node. Taints are
+// registered through kubeadm, as kind has no field for them.
+func kindNodeFromConfig(cn *config.Node) (kindconfig.Node, error) {
+	n := kindconfig.Node{
+		Role:                 kindconfig.ControlPlaneRole,
+		Labels:               cn.Labels,
+		KubeadmConfigPatches: append([]string{}, cn.KubeadmConfigPatches...),
+	}
+	if cn.Role == config.NodeRoleWorker {
+		n.Role = kindconfig.WorkerRole
+	}
+
+	for _, m := range cn.ExtraMounts {
+		n.ExtraMounts = append(n.ExtraMounts, kindconfig.Mount{
+			HostPath:      m.HostPath,
+			ContainerPath: m.ContainerPath,
+			Readonly:      m.ReadOnly,
+		})
+	}
+	for _, pm := range cn.ExtraPortMappings {
+		n.ExtraPortMappings = append(n.ExtraPortMappings, kindPortMapping(pm))
+	}
+
+	if len(cn.Taints) != 0 {
+		patches, err := taintPatches(cn.Taints)
+		if err != nil {
+			return kindconfig.Node{}, err
+		}
+		n.KubeadmConfigPatches = append(n.KubeadmConfigPatches, patches...)
+	}
+	return n, nil
+}
+
+// kindPortMapping converts a port mapping of the configuration to a kind port mapping.
+func kindPortMapping(pm config.PortMap) kindconfig.PortMapping {
+	portMapping := kindconfig.PortMapping{}
+	if pm.ContainerPort != 0 {
+		portMapping.ContainerPort = int32(pm.ContainerPort)
+	}
+	if pm.HostPort != 0 {
+		portMapping.HostPort = int32(pm.HostPort)
+	}
+	if pm.Protocol != "" {
+		portMapping.Protocol = kindconfig.PortMappingProtocol(pm.Protocol)
+	}
+	return portMapping
+}
+
+// ValidateNodes returns an error if a node customized in the configuration is invalid, or if a
+// cluster cannot be created with the nodes.
+func ValidateNodes(nodes []config.Node) error {
+	cpnc, wnc := 0, 0
+	for i := range nodes {
+		n := &nodes[i]
+		switch n.Role {
+		case "", config.NodeRoleControlPlane:
+			cpnc++
+		case config.NodeRoleWorker:
+			wnc++
+		default:
+			return fmt.Errorf("node %d has unknown role %q, expected %s or %s", i, n.Role, config.NodeRoleControlPlane, config.NodeRoleWorker)
+		}
+
+		for _, taint := range n.Taints {
+			if _, err := parseTaint(taint); err != nil {
+				return fmt.Errorf("node %d: %s", i, err.Error())
+			}
+		}
+		for _, m := range n.ExtraMounts {
+			if m.HostPath == "" || m.ContainerPath == "" {
+				return fmt.Errorf("node %d: extra mounts require both HostPath and ContainerPath", i)
+			}
+		}
+		for _, pm := range n.ExtraPortMappings {
+			if err := validatePortMap(pm); err != nil {
+				return fmt.Errorf("node %d: %s", i, err.Error())
+			}
+		}
+		if err := ValidateKubeadmConfigPatches(n.KubeadmConfigPatches); err != nil {
+			return fmt.Errorf("node %d: %s", i, err.Error())
+		}
+	}
+
+	return validateNodeCounts(cpnc, wnc)
+}
+
+// validatePortMap returns an error if a port mapping cannot be applied to a node.
+func validatePortMap(pm config.PortMap) error {
+	if pm.ContainerPort < 1 || pm.ContainerPort > maxPort {
+		return fmt.Errorf("invalid container port %d", pm.ContainerPort)
+	}
+	if pm.HostPort < 0 || pm.HostPort > maxPort {
+		return fmt.Errorf("invalid host port %d", pm.HostPort)
+	}
+	switch strings.ToLower(pm.Protocol) {
+	case "", config.ProtocolTCP, config.ProtocolUDP, config.ProtocolSCTP:
+		return nil
+	default:
+		return fmt.Errorf("invalid protocol %q, must be tcp, udp, or sctp", pm.Protocol)
+	}
+}
+
+// ValidateKubeadmConfigPatches returns an error if a patch is not a YAML document naming the kind
+// of kubeadm configuration it patches.
+func ValidateKubeadmConfigPatches(patches []string) error {
+	for _, patch := range patches {
+		doc := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(patch), &doc)
+		if err != nil {
+			return fmt.Errorf("invalid kubeadm config patch. Error: %s", err.Error())
+		}
+		if kind, ok := doc["kind"].(string); !ok || kind == "" {
+			return fmt.Errorf("kubeadm config patches must set the kind of configuration they patch")
+		}
+	}
+	return nil
+}
+
+// kubeadmTaint is a taint as registered by kubeadm in a node's nodeRegistration.
+type kubeadmTaint struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value,omitempty"`
+	Effect string `yaml:"effect"`
+}
+
+// parseTaint parses a taint in the format key[=value]:effect, as used by kubectl taint.
+func parseTaint(taint string) (kubeadmTaint, error) {
+	i := strings.LastIndex(taint, ":")
+	if i < 1 {
+		return kubeadmTaint{}, fmt.Errorf("invalid taint %q, expected key[=value]:effect", taint)
+	}
+
+	t := kubeadmTaint{Key: taint[:i], Effect: taint[i+1:]}
+	switch t.Effect {
+	case "NoSchedule", "PreferNoSchedule", "NoExecute":
+	default:
+		return kubeadmTaint{}, fmt.Errorf("invalid taint %q, effect must be NoSchedule, PreferNoSchedule or NoExecute", taint)
+	}
+	if j := strings.Index(t.Key, "="); j >= 0 {
+		t.Key, t.Value = t.Key[:j], t.Key[j+1:]
+	}
+	if t.Key == "" {
+		return kubeadmTaint{}, fmt.Errorf("invalid taint %q, expected key[=value]:effect", taint)
+	}
+	return t, nil
+}
+
+// kubeadmNodeRegistration is the part of kubeadm's InitConfiguration and JoinConfiguration that
+// registers a node.
+type kubeadmNodeRegistration struct {
+	Taints           []kubeadmTaint    `yaml:"taints,omitempty"`
+	KubeletExtraArgs map[string]string `yaml:"kubeletExtraArgs,omitempty"`
+}
+
+// kubeadmAPIServer is the part of kubeadm's ClusterConfiguration that configures the API server.
+type kubeadmAPIServer struct {
+	ExtraArgs map[string]string `yaml:"extraArgs"`
+}
+
+// kubeadmConfigPatch is a merge patch of a kubeadm configuration.
+type kubeadmConfigPatch struct {
+	Kind             string                   `yaml:"kind"`
+	NodeRegistration *kubeadmNodeRegistration `yaml:"nodeRegistration,omitempty"`
+	APIServer        *kubeadmAPIServer        `yaml:"apiServer,omitempty"`
+}
+
+// nodeRegistrationPatches returns kubeadm config patches registering nodes as described. kind
+// renders both an InitConfiguration and a JoinConfiguration for every node, so both are patched.
+func nodeRegistrationPatches(registration *kubeadmNodeRegistration) ([]string, error) {
+	patches := []string{}
+	for _, kind := range []string{"InitConfiguration", "JoinConfiguration"} {
+		patch, err := yaml.Marshal(kubeadmConfigPatch{Kind: kind, NodeRegistration: registration})
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, string(patch))
+	}
+	return patches, nil
+}
+
+// taintPatches returns kubeadm config patches registering the node with the taints.
+func taintPatches(taints []string) ([]string, error) {
+	registration := &kubeadmNodeRegistration{}
+	for _, taint := range taints {
+		t, err := parseTaint(taint)
+		if err != nil {
+			return nil, err
+		}
+		registration.Taints = append(registration.Taints, t)
+	}
+	return nodeRegistrationPatches(registration)
+}
+
+// clusterKubeadmConfigPatches returns the kubeadm config patches applied to every node. Patches
+// passing the configured API server and kubelet arguments come first, so the configured patches
+// take precedence.
+func clusterKubeadmConfigPatches(c *config.UnmanagedClusterConfig) ([]string, error) {
+	patches := []string{}
+	if len(c.APIServerExtraArgs) != 0 {
+		patch, err := yaml.Marshal(kubeadmConfigPatch{
+			Kind:      "ClusterConfiguration",
+			APIServer: &kubeadmAPIServer{ExtraArgs: c.APIServerExtraArgs},
+		})
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, string(patch))
+	}
+	if len(c.KubeletExtraArgs) != 0 {
+		kubeletPatches, err := nodeRegistrationPatches(&kubeadmNodeRegistration{KubeletExtraArgs: c.KubeletExtraArgs})
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, kubeletPatches...)
+	}
+	return append(patches, c.KubeadmConfigPatches...), nil
+}
+
+// Get retrieves the nodes, their state and the kubeconfig of a kind cluster by inspecting its
+// node containers.
+func (kcm KindClusterManager) Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	provider := kcm.runtime.kindProvider()
+	kc := &KubernetesCluster{
+		Name:   c.ClusterName,
+		Status: StatusMissing,
+	}
+
+	kindNodes, err := provider.ListNodes(c.ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	if len(kindNodes) == 0 {
+		return kc, nil
+	}
+
+	kc.Status = StatusRunning
+	for _, n := range kindNodes {
+		output, err := kcm.runtime.Inspect(n.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to inspect node %s. Error: %s", n.String(), err.Error())
+		}
+		node, err := parseDockerContainer(output)
+		if err != nil {
+			return nil, err
+		}
+		node.Name = n.String()
+		node.Role, _ = n.Role()
+
+		if node.Status != dockerStatusRunning {
+			kc.Status = StatusStopped
+		}
+		kc.NodeImage = node.Image
+		kc.Nodes = append(kc.Nodes, node)
+	}
+	kc.setCreatedAt()
+
+	// The kubeconfig is written when the cluster is created. If it's no longer there, it can only be
+	// retrieved from a running control plane.
+	kc.Kubeconfig, err = os.ReadFile(c.KubeconfigPath)
+	if err != nil && kc.Status == StatusRunning {
+		kubeconfig, kerr := provider.KubeConfig(c.ClusterName, false)
+		if kerr == nil {
+			kc.Kubeconfig = []byte(kubeconfig)
+		}
+	}
+	kc.Endpoint = endpointFromKubeconfig(kc.Kubeconfig)
+
+	return kc, nil
 }
 
 // Delete removes a kind cluster.
 func (kcm KindClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
-	provider := kindcluster.NewProvider()
+	provider := kcm.runtime.kindProvider()
 	return provider.Delete(c.ClusterName, "")
 }
 
-// Prepare will fetch a container image to the cluster host.
-func (kcm KindClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
-	cmd := exec.Command("docker", "pull", c.NodeImage)
-	_, err := exec.Output(cmd)
+// Stop stops the node containers of a kind cluster. The containers are kept so the cluster can be
+// started again.
+func (kcm KindClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	nodeNames, err := listKindNodeNames(kcm.runtime, c.ClusterName)
 	if err != nil {
 		return err
 	}
+
+	err = kcm.runtime.Stop(nodeNames...)
+	if err != nil {
+		return fmt.Errorf("failed to stop nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
 	return nil
 }
 
-// PreflightCheck performs any pre-checks that can find issues up front that
-// would cause problems for cluster creation.
-func (kcm KindClusterManager) PreflightCheck() ([]string, []error) {
-	// Check presence of docker
-	cmd := exec.Command("docker", "ps")
-	if err := cmd.Run(); err != nil {
-		// In this case we can't check the rest of the settings, so just return
-		// the one error.
-		return []string{}, []error{fmt.Errorf("docker is not installed or not reachable. Verify it's installed, running, and your user has permissions to interact with it. Error when attempting to run docker ps: %w", err)}
+// Start starts the node containers of a stopped kind cluster.
+func (kcm KindClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	nodeNames, err := listKindNodeNames(kcm.runtime, c.ClusterName)
+	if err != nil {
+		return err
 	}
 
-	// Get Docker info
-	cmd = exec.Command("docker", "info", "--format", "{{ json . }}")
-	output, err := exec.Output(cmd)
+	err = kcm.runtime.Start(nodeNames...)
 	if err != nil {
-		return []string{}, []error{fmt.Errorf("unable to get docker info: %w", err)}
+		return fmt.Errorf("failed to start nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
 	}
 
-	return validateDockerInfo(output)
+	// The network settings patched for the CNI are reset when a node's container restarts
+	if strings.Contains(c.Cni, "antrea") {
+		for _, nodeName := range nodeNames {
+			// TODO(stmcginnis): As during create, failing to patch a node is not reported.
+			_ = patchForAntrea(kcm.runtime, nodeName)
+		}
+	}
+	if strings.Contains(c.Cni, "calico") {
+		for _, nodeName := range nodeNames {
+			if err := patchForCalico(kcm.runtime, nodeName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
-// ProviderNotify returns the kind provider notification used during cluster bootstrapping
-func (kcm KindClusterManager) ProviderNotify() []string {
-	return []string{
-		"Cluster creation using kind!",
-		"❤️  Checkout this awesome project at https://kind.sigs.k8s.io",
+// listKindNodeNames returns the names of the node containers of a kind cluster.
+func listKindNodeNames(runtime ContainerRuntime, clusterName string) ([]string, error) {
+	provider := runtime.kindProvider()
+	nodes, err := provider.ListNodes(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes of cluster %s. Error: %s", clusterName, err.Error())
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes found for cluster %s", clusterName)
 	}
-}
 
-type dockerInfo struct {
-	CPUs         int    `json:"NCPU"`
-	Memory       int64  `json:"MemTotal"`
-	Architecture string `json:"Architecture"`
+	nodeNames := []string{}
+	for _, n := range nodes {
+		nodeNames = append(nodeNames, n.String())
+	}
+	return nodeNames, nil
 }
 
-func validateDockerInfo(output []byte) ([]string, []error) {
-	info := dockerInfo{}
-	if err := json.Unmarshal(output, &info); err != nil {
-		// Nothing else we can check, just return this error right away
-		return nil, []error{errors.New("unable to parse Docker information")}
+// Prepare will fetch a container image to the cluster host.
+func (kcm KindClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
+	// Bundles load the node image into docker, as the registry it is from may not be reachable
+	if c.FromBundle != "" {
+		if !kcm.runtime.ImageExists(c.NodeImage) {
+			return fmt.Errorf("node image %s was not loaded from bundle %s", c.NodeImage, c.FromBundle)
+		}
+		return nil
 	}
 
-	warnings := []string{}
-	issues := []error{}
+	return kcm.runtime.Pull(c.NodeImage)
+}
 
-	if !strings.HasSuffix(info.Architecture, "x86_64") {
-		// Only amd64 supported right now, arm is experimental. Anything else is not supported.
-		if strings.HasSuffix(info.Architecture, "aarch64") {
-			warnings = append(warnings, "Arm64 architecture detected. Support is currently experimental. Some packages may not install due to their arm64 image not being available. You can find a list of package that have arm support in the release notes at https://github.com/vmware-tanzu/community-edition/releases/tag/v0.11.0.")
-		} else {
-			return []string{}, []error{errors.New("only amd64 and arm64 (experimental) architectures are currently supported")}
+// PreflightCheck performs any pre-checks that can find issues up front that
+// would cause problems for cluster creation.
+func (kcm KindClusterManager) PreflightCheck() ([]string, []error) {
+	warnings, errs := kcm.runtime.PreflightCheck()
+	if len(errs) == 0 && (kcm.ipFamily == config.IPFamilyIPv6 || kcm.ipFamily == config.IPFamilyDual) {
+		if err := kcm.runtime.CheckIPv6(); err != nil {
+			errs = append(errs, err)
 		}
 	}
+	return warnings, errs
+}
 
-	if info.CPUs < minCPUCount {
-		// Should only hit this if there is an issue getting the docker info
-		// correctly, but we can also raise this if we find the need
-		issues = append(issues, fmt.Errorf("minimum %d CPU core is required", minCPUCount))
+// ProviderNotify returns the kind provider notification used during cluster bootstrapping
+func (kcm KindClusterManager) ProviderNotify() []string {
+	return []string{
+		"Cluster creation using kind!",
+		"❤️  Checkout this awesome project at https://kind.sigs.k8s.io",
 	}
+}
 
-	if info.Memory < minMemoryBytes {
-		issues = append(issues, fmt.Errorf("minimum %d GiB of memory is required", (minMemoryBytes/1024/1024/1024)))
+const dockerStatusRunning = "running"
+
+type dockerContainer struct {
+	Created time.Time `json:"Created"`
+	Config  struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+	State struct {
+		Status string `json:"Status"`
+	} `json:"State"`
+	HostConfig struct {
+		PortBindings map[string][]dockerPortBinding `json:"PortBindings"`
+	} `json:"HostConfig"`
+}
+
+type dockerPortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// parseDockerContainer reads the image, state and forwarded ports of a node from the output of
+// docker inspect. Port bindings are read from the host configuration, as they are only reported
+// in the network settings while the container is running.
+func parseDockerContainer(output []byte) (Node, error) {
+	container := dockerContainer{}
+	if err := json.Unmarshal(output, &container); err != nil {
+		return Node{}, fmt.Errorf("unable to parse node container information. Error: %s", err.Error())
+	}
+
+	node := Node{
+		Image:     container.Config.Image,
+		Status:    container.State.Status,
+		CreatedAt: container.Created,
+	}
+	for containerPort, bindings := range container.HostConfig.PortBindings {
+		for _, binding := range bindings {
+			hostIP := binding.HostIP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			node.Ports = append(node.Ports, fmt.Sprintf("%s:%s->%s", hostIP, binding.HostPort, containerPort))
+		}
 	}
+	sort.Strings(node.Ports)
 
-	return warnings, issues
+	return node, nil
 }
 
 // patchForAntrea modifies the node network settings to allow local routing.
 // this needs to happen for antrea running on kind or else you'll lose network connectivity
 // see: https://github.com/antrea-io/antrea/blob/main/hack/kind-fix-networking.sh
-func patchForAntrea(nodeName string) error {
+func patchForAntrea(runtime ContainerRuntime, nodeName string) error {
 	// First need to get the ID of the interface from the cluster node.
-	cmd := exec.Command("docker", "exec", nodeName, "ip", "link")
-	out, err := exec.Output(cmd)
+	out, err := runtime.Exec(nodeName, "ip", "link")
 	if err != nil {
 		return err
 	}
@@ -371,7 +797,7 @@ func patchForAntrea(nodeName string) error {
 	peerIdx := match[1]
 
 	// Now that we have the ID, we need to look on the host network to find its name.
-	cmd = exec.Command("docker", "run", "--rm", "--net=host", "antrea/ethtool:latest", "ip", "link")
+	cmd := runtime.Command("run", "--rm", "--net=host", "antrea/ethtool:latest", "ip", "link")
 	outLines, err := exec.OutputLines(cmd)
 	if err != nil {
 		return err
@@ -391,18 +817,28 @@ func patchForAntrea(nodeName string) error {
 	}
 
 	// With the name, we can now use ethtool to turn off TX checksumming offload
-	cmd = exec.Command("docker", "run", "--rm", "--net=host", "--privileged", "antrea/ethtool:latest", "ethtool", "-K", peerName, "tx", "off")
+	cmd = runtime.Command("run", "--rm", "--net=host", "--privileged", "antrea/ethtool:latest", "ethtool", "-K", peerName, "tx", "off")
 	_, err = exec.Output(cmd)
 	if err != nil {
 		return err
 	}
 
 	// Finally, enable local routing
-	cmd = exec.Command("docker", "exec", nodeName, "sysctl", "-w", "net.ipv4.conf.all.route_localnet=1")
-	_, err = exec.Output(cmd)
+	_, err = runtime.Exec(nodeName, "sysctl", "-w", "net.ipv4.conf.all.route_localnet=1")
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// patchForCalico disables reverse path filtering on the node. Docker hosts commonly set loose
+// reverse path filtering, which calico's felix refuses to run with, leaving calico-node unready.
+// see: https://projectcalico.docs.tigera.io/reference/felix/configuration
+func patchForCalico(runtime ContainerRuntime, nodeName string) error {
+	_, err := runtime.Exec(nodeName, "sysctl", "-w", "net.ipv4.conf.all.rp_filter=0")
+	if err != nil {
+		return fmt.Errorf("failed to disable reverse path filtering of node %s for calico. Error: %s", nodeName, err.Error())
+	}
+	return nil
+}
@@ -5,7 +5,14 @@ package cluster
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	kindconfig "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
 )
 
 var normalDockerInfoJSON = `{"ID":"SEB7:L67H:GZMX:VPIN:YZ7V:RTRC:DCML:3C7C:PNN3:2DQA:6GD2:ZIWU","Containers":7,"ContainersRunning":1,"ContainersPaused":0,"ContainersStopped":6,"Images":151,"Driver":"overlay2","DriverStatus":[["Backing Filesystem","extfs"],["Supports d_type","true"],["Native Overlay Diff","true"],["userxattr","false"]],"Plugins":{"Volume":["local"],"Network":["bridge","host","ipvlan","macvlan","null","overlay"],"Authorization":null,"Log":["awslogs","fluentd","gcplogs","gelf","journald","json-file","local","logentries","splunk","syslog"]},"MemoryLimit":true,"SwapLimit":true,"KernelMemory":true,"KernelMemoryTCP":true,"CpuCfsPeriod":true,"CpuCfsQuota":true,"CPUShares":true,"CPUSet":true,"PidsLimit":true,"IPv4Forwarding":true,"BridgeNfIptables":true,"BridgeNfIp6tables":true,"Debug":false,"NFd":32,"OomKillDisable":true,"NGoroutines":40,"SystemTime":"2022-01-11T15:43:55.314860422-06:00","LoggingDriver":"json-file","CgroupDriver":"cgroupfs","CgroupVersion":"1","NEventsListener":0,"KernelVersion":"5.11.0-43-generic","OperatingSystem":"Ubuntu 20.04.3 LTS","OSVersion":"20.04","OSType":"linux","Architecture":"x86_64","IndexServerAddress":"https://index.docker.io/v1/","RegistryConfig":{"AllowNondistributableArtifactsCIDRs":[],"AllowNondistributableArtifactsHostnames":[],"InsecureRegistryCIDRs":["127.0.0.0/8"],"IndexConfigs":{"docker.io":{"Name":"docker.io","Mirrors":[],"Secure":true,"Official":true}},"Mirrors":[]},"NCPU":16,"MemTotal":33613119488,"GenericResources":null,"DockerRootDir":"/var/lib/docker","HttpProxy":"","HttpsProxy":"","NoProxy":"","Name":"sm-workstation","Labels":[],"ExperimentalBuild":false,"ServerVersion":"20.10.12","Runtimes":{"io.containerd.runc.v2":{"path":"runc"},"io.containerd.runtime.v1.linux":{"path":"runc"},"runc":{"path":"runc"}},"DefaultRuntime":"runc","Swarm":{"NodeID":"","NodeAddr":"","LocalNodeState":"inactive","ControlAvailable":false,"Error":"","RemoteManagers":null},"LiveRestoreEnabled":false,"Isolation":"","InitBinary":"docker-init","ContainerdCommit":{"ID":"7b11cfaabd73bb80907dd23182b9347b4245eb5d","Expected":"7b11cfaabd73bb80907dd23182b9347b4245eb5d"},"RuncCommit":{"ID":"v1.0.2-0-g52b36a2","Expected":"v1.0.2-0-g52b36a2"},"InitCommit":{"ID":"de40ad0","Expected":"de40ad0"},"SecurityOptions":["name=apparmor","name=seccomp,profile=default"],"Warnings":null,"ClientInfo":{"Debug":false,"Context":"default","Plugins":[{"SchemaVersion":"0.1.0","Vendor":"Docker Inc.","Version":"v0.9.1-beta3","ShortDescription":"Docker App","Experimental":true,"Name":"app","Path":"/usr/libexec/docker/cli-plugins/docker-app"},{"SchemaVersion":"0.1.0","Vendor":"Docker Inc.","Version":"v0.7.1-docker","ShortDescription":"Docker Buildx","Name":"buildx","Path":"/usr/libexec/docker/cli-plugins/docker-buildx"},{"SchemaVersion":"0.1.0","Vendor":"Docker Inc.","Version":"v0.12.0","ShortDescription":"Docker Scan","Name":"scan","Path":"/usr/libexec/docker/cli-plugins/docker-scan"}],"Warnings":null}}`
@@ -94,3 +101,248 @@ func TestValidateDockerInfoBadData(t *testing.T) {
 		t.Errorf("expected 1 error but %d returned", len(errs))
 	}
 }
+
+var stoppedNodeContainerJSON = `{"Id":"2a6bd3c0a7a1","Created":"2022-04-01T17:01:12.123456789Z","State":{"Status":"exited","Running":false,"ExitCode":137},"Config":{"Hostname":"test-control-plane","Image":"projects.registry.vmware.com/tce/kind:v1.22.7"},"HostConfig":{"PortBindings":{"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"41915"}],"80/tcp":[{"HostIp":"","HostPort":"80"}]}},"NetworkSettings":{"Ports":{}}}`
+
+func TestParseDockerContainerStoppedNode(t *testing.T) {
+	node, err := parseDockerContainer([]byte(stoppedNodeContainerJSON))
+	if err != nil {
+		t.Fatalf("unexpected error parsing container: %s", err)
+	}
+	if node.Image != "projects.registry.vmware.com/tce/kind:v1.22.7" {
+		t.Errorf("unexpected image %q", node.Image)
+	}
+	if node.Status != "exited" {
+		t.Errorf("expected status exited but got %q", node.Status)
+	}
+	if node.CreatedAt.Format(time.RFC3339) != "2022-04-01T17:01:12Z" {
+		t.Errorf("unexpected creation time %s", node.CreatedAt)
+	}
+	if len(node.Ports) != 2 {
+		t.Fatalf("expected 2 ports but %d returned", len(node.Ports))
+	}
+	if node.Ports[0] != "0.0.0.0:80->80/tcp" {
+		t.Errorf("unexpected port mapping %q", node.Ports[0])
+	}
+	if node.Ports[1] != "127.0.0.1:41915->6443/tcp" {
+		t.Errorf("unexpected port mapping %q", node.Ports[1])
+	}
+}
+
+func TestParseDockerContainerBadData(t *testing.T) {
+	_, err := parseDockerContainer([]byte{240, 159, 146, 169})
+	if err == nil {
+		t.Error("expected an error parsing invalid data")
+	}
+}
+
+func TestRegistryConfigPatch(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		RegistryMirrors: []config.RegistryMirror{
+			{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com", "http://10.0.0.1:5000"}},
+		},
+		InsecureRegistries: []string{"mirror.example.com"},
+	}
+
+	expected := `[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://mirror.example.com", "http://10.0.0.1:5000"]
+[plugins."io.containerd.grpc.v1.cri".registry.configs."mirror.example.com".tls]
+  insecure_skip_verify = true
+`
+	if patch := registryConfigPatch(c); patch != expected {
+		t.Errorf("unexpected containerd patch:\n%s", patch)
+	}
+}
+
+func TestRegistryConfigPatchEmpty(t *testing.T) {
+	if patch := registryConfigPatch(&config.UnmanagedClusterConfig{}); patch != "" {
+		t.Errorf("expected no containerd patch but got:\n%s", patch)
+	}
+}
+
+func TestKindConfigFromClusterConfigNodes(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName: "test",
+		NodeImage:   "kindest/node:v1.22.4",
+		PortsToForward: []config.PortMap{
+			{ContainerPort: 80, HostPort: 8080},
+		},
+		Nodes: []config.Node{
+			{
+				Role:   config.NodeRoleControlPlane,
+				Labels: map[string]string{"ingress-ready": "true"},
+			},
+			{
+				Role:              config.NodeRoleWorker,
+				Taints:            []string{"dedicated=gpu:NoSchedule"},
+				ExtraMounts:       []config.Mount{{HostPath: "/data", ContainerPath: "/mnt/data", ReadOnly: true}},
+				ExtraPortMappings: []config.PortMap{{ContainerPort: 443, HostPort: 8443, Protocol: "tcp"}},
+			},
+		},
+	}
+
+	raw, err := kindConfigFromClusterConfig(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kindConfig := kindconfig.Cluster{}
+	err = yaml.Unmarshal(raw, &kindConfig)
+	if err != nil {
+		t.Fatalf("generated config is not valid YAML: %s", err)
+	}
+
+	if len(kindConfig.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes but got %d", len(kindConfig.Nodes))
+	}
+	cp, worker := kindConfig.Nodes[0], kindConfig.Nodes[1]
+	if cp.Role != kindconfig.ControlPlaneRole || cp.Labels["ingress-ready"] != "true" || cp.Image != c.NodeImage {
+		t.Errorf("unexpected control plane node %+v", cp)
+	}
+	if len(cp.ExtraPortMappings) != 1 || cp.ExtraPortMappings[0].HostPort != 8080 {
+		t.Errorf("expected PortsToForward on the first node, got %+v", cp.ExtraPortMappings)
+	}
+	if worker.Role != kindconfig.WorkerRole || worker.Image != c.NodeImage {
+		t.Errorf("unexpected worker node %+v", worker)
+	}
+	if len(worker.ExtraMounts) != 1 || worker.ExtraMounts[0].ContainerPath != "/mnt/data" || !worker.ExtraMounts[0].Readonly {
+		t.Errorf("unexpected worker mounts %+v", worker.ExtraMounts)
+	}
+	if len(worker.ExtraPortMappings) != 1 || worker.ExtraPortMappings[0].ContainerPort != 443 {
+		t.Errorf("unexpected worker port mappings %+v", worker.ExtraPortMappings)
+	}
+	if len(worker.KubeadmConfigPatches) != 2 || !strings.Contains(worker.KubeadmConfigPatches[1], "kind: JoinConfiguration") ||
+		!strings.Contains(worker.KubeadmConfigPatches[1], "key: dedicated") {
+		t.Errorf("expected taint patches for the worker, got %v", worker.KubeadmConfigPatches)
+	}
+}
+
+func TestValidateNodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []config.Node
+		valid bool
+	}{
+		{"default role", []config.Node{{}}, true},
+		{"control plane and workers", []config.Node{{Role: "control-plane"}, {Role: "worker"}, {Role: "worker"}}, true},
+		{"multiple control planes", []config.Node{{}, {}, {}}, true},
+		{"unknown role", []config.Node{{Role: "master"}}, false},
+		{"no control plane", []config.Node{{Role: "worker"}}, false},
+		{"invalid taint", []config.Node{{Taints: []string{"dedicated=gpu"}}}, false},
+		{"incomplete mount", []config.Node{{ExtraMounts: []config.Mount{{HostPath: "/data"}}}}, false},
+		{"invalid port", []config.Node{{ExtraPortMappings: []config.PortMap{{ContainerPort: 70000}}}}, false},
+		{"invalid protocol", []config.Node{{ExtraPortMappings: []config.PortMap{{ContainerPort: 80, Protocol: "icmp"}}}}, false},
+		{"patch without kind", []config.Node{{KubeadmConfigPatches: []string{"nodeRegistration: {}"}}}, false},
+		{"patch", []config.Node{{KubeadmConfigPatches: []string{"kind: InitConfiguration\nnodeRegistration: {}"}}}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateNodes(tt.nodes)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	taint, err := parseTaint("dedicated=gpu:NoSchedule")
+	if err != nil || taint.Key != "dedicated" || taint.Value != "gpu" || taint.Effect != "NoSchedule" {
+		t.Errorf("unexpected taint %+v, error: %v", taint, err)
+	}
+
+	taint, err = parseTaint("example.com/special:NoExecute")
+	if err != nil || taint.Key != "example.com/special" || taint.Value != "" || taint.Effect != "NoExecute" {
+		t.Errorf("unexpected taint %+v, error: %v", taint, err)
+	}
+
+	for _, invalid := range []string{"dedicated", ":NoSchedule", "=gpu:NoSchedule", "dedicated:Never"} {
+		if _, err := parseTaint(invalid); err == nil {
+			t.Errorf("expected an error for taint %q", invalid)
+		}
+	}
+}
+
+func TestKindConfigFromClusterConfigKubernetesCustomization(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		ControlPlaneNodeCount: "1",
+		WorkerNodeCount:       "0",
+		FeatureGates:          map[string]bool{"EphemeralContainers": true},
+		RuntimeConfig:         map[string]string{"api/alpha": "true"},
+		APIServerExtraArgs:    map[string]string{"enable-admission-plugins": "NodeRestriction,PodSecurity"},
+		KubeletExtraArgs:      map[string]string{"max-pods": "200"},
+		KubeadmConfigPatches:  []string{"kind: ClusterConfiguration\napiServer:\n  certSANs:\n  - example.com\n"},
+	}
+
+	raw, err := kindConfigFromClusterConfig(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kindConfig := kindconfig.Cluster{}
+	err = yaml.Unmarshal(raw, &kindConfig)
+	if err != nil {
+		t.Fatalf("generated config is not valid YAML: %s", err)
+	}
+
+	if !kindConfig.FeatureGates["EphemeralContainers"] || kindConfig.RuntimeConfig["api/alpha"] != "true" {
+		t.Errorf("unexpected feature gates %v or runtime config %v", kindConfig.FeatureGates, kindConfig.RuntimeConfig)
+	}
+
+	patches := kindConfig.KubeadmConfigPatches
+	if len(patches) != 4 {
+		t.Fatalf("expected 4 kubeadm config patches but got %d: %v", len(patches), patches)
+	}
+	expected := []string{
+		"kind: ClusterConfiguration\napiServer:\n    extraArgs:\n        enable-admission-plugins: NodeRestriction,PodSecurity\n",
+		"kind: InitConfiguration\nnodeRegistration:\n    kubeletExtraArgs:\n        max-pods: \"200\"\n",
+		"kind: JoinConfiguration\nnodeRegistration:\n    kubeletExtraArgs:\n        max-pods: \"200\"\n",
+		c.KubeadmConfigPatches[0],
+	}
+	for i := range expected {
+		if patches[i] != expected[i] {
+			t.Errorf("unexpected kubeadm config patch %d:\n%s", i, patches[i])
+		}
+	}
+}
+
+func TestKindConfigFromClusterConfigDualStack(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		ControlPlaneNodeCount: "1",
+		WorkerNodeCount:       "0",
+		IPFamily:              config.IPFamilyDual,
+		PodCidr:               "10.244.0.0/16,fd00:10:244::/56",
+		ServiceCidr:           "10.96.0.0/16,fd00:10:96::/112",
+	}
+
+	raw, err := kindConfigFromClusterConfig(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kindConfig := kindconfig.Cluster{}
+	err = yaml.Unmarshal(raw, &kindConfig)
+	if err != nil {
+		t.Fatalf("generated config is not valid YAML: %s", err)
+	}
+
+	if kindConfig.Networking.IPFamily != kindconfig.DualStackFamily {
+		t.Errorf("expected a dual-stack cluster but got %q", kindConfig.Networking.IPFamily)
+	}
+	if kindConfig.Networking.PodSubnet != c.PodCidr || kindConfig.Networking.ServiceSubnet != c.ServiceCidr {
+		t.Errorf("unexpected subnets %s and %s", kindConfig.Networking.PodSubnet, kindConfig.Networking.ServiceSubnet)
+	}
+}
+
+func TestValidateKubeadmConfigPatches(t *testing.T) {
+	if err := ValidateKubeadmConfigPatches([]string{"kind: KubeletConfiguration\nmaxPods: 200"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ValidateKubeadmConfigPatches([]string{"maxPods: 200"}); err == nil {
+		t.Error("expected an error for a patch without a kind")
+	}
+	if err := ValidateKubeadmConfigPatches([]string{"kind: [KubeletConfiguration"}); err == nil {
+		t.Error("expected an error for a patch that is not valid YAML")
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+const (
+	minikubeHostRunning     = "Running"
+	minikubeHostNonexistent = "Nonexistent"
+)
+
+// nodeImageVersion matches the Kubernetes version in the tag of a node image, such as v1.22.5 in
+// projects.registry.vmware.com/tce/kind/node:v1.22.5.
+var nodeImageVersion = regexp.MustCompile(`:(v\d+\.\d+\.\d+)`)
+
+// MinikubeClusterManager is a ClusterManager implementation for working with minikube clusters.
+// Clusters are minikube profiles, created with the docker driver.
+type MinikubeClusterManager struct {
+}
+
+// minikubeNodeStatus is the status minikube reports for each node of a profile.
+type minikubeNodeStatus struct {
+	Name   string `json:"Name"`
+	Host   string `json:"Host"`
+	Worker bool   `json:"Worker"`
+}
+
+// Create will create a new minikube cluster or return an error.
+func (mcm MinikubeClusterManager) Create(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	args, err := minikubeStartArgs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = minikubeCommand(c, args...)
+	if err != nil {
+		return nil, fmt.Errorf("minikube returned error: %s", err)
+	}
+
+	kcBytes, err := os.ReadFile(c.KubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// minikube names the context after the profile, which is the cluster name
+	kc := &KubernetesCluster{
+		Name:        c.ClusterName,
+		Kubeconfig:  kcBytes,
+		ContextName: c.ClusterName,
+	}
+
+	// Node containers are named after their minikube nodes
+	if strings.Contains(c.Cni, "antrea") {
+		statuses, _ := minikubeStatus(c)
+		for _, s := range statuses {
+			// TODO(stmcginnis): As with kind, failing to patch a node is not reported.
+			_ = patchForAntrea(dockerRuntime, s.Name)
+		}
+	}
+	if strings.Contains(c.Cni, "calico") {
+		statuses, _ := minikubeStatus(c)
+		for _, s := range statuses {
+			// As with kind, a cluster whose nodes cannot run calico is deleted rather than returned
+			if err := patchForCalico(dockerRuntime, s.Name); err != nil {
+				_ = mcm.Delete(c)
+				return nil, err
+			}
+		}
+	}
+
+	return kc, nil
+}
+
+// Get retrieves the nodes, their state and the kubeconfig of a minikube cluster.
+func (mcm MinikubeClusterManager) Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	kc := &KubernetesCluster{
+		Name:   c.ClusterName,
+		Status: StatusMissing,
+	}
+
+	statuses, err := minikubeStatus(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return kc, nil
+	}
+
+	kc.Status = StatusRunning
+	for _, s := range statuses {
+		node := Node{
+			Name:   s.Name,
+			Role:   ControlPlaneRole,
+			Status: s.Host,
+		}
+		if s.Worker {
+			node.Role = WorkerRole
+		}
+		if s.Host != minikubeHostRunning {
+			kc.Status = StatusStopped
+		}
+		kc.Nodes = append(kc.Nodes, node)
+	}
+
+	kc.Kubeconfig, _ = os.ReadFile(c.KubeconfigPath)
+	kc.Endpoint = endpointFromKubeconfig(kc.Kubeconfig)
+
+	return kc, nil
+}
+
+// Delete removes a minikube cluster.
+func (mcm MinikubeClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
+	_, err := minikubeCommand(c, "delete", "--profile", c.ClusterName)
+	if err != nil {
+		return fmt.Errorf("failed to delete cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	return nil
+}
+
+// Stop stops the nodes of a minikube cluster.
+func (mcm MinikubeClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	_, err := minikubeCommand(c, "stop", "--profile", c.ClusterName)
+	if err != nil {
+		return fmt.Errorf("failed to stop nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+	return nil
+}
+
+// Start starts the nodes of a stopped minikube cluster.
+func (mcm MinikubeClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	_, err := minikubeCommand(c, "start", "--profile", c.ClusterName)
+	if err != nil {
+		return fmt.Errorf("failed to start nodes of cluster %s. Error: %s", c.ClusterName, err.Error())
+	}
+
+	// As with kind, the network settings patched for the CNI are reset when a node restarts
+	statuses, _ := minikubeStatus(c)
+	for _, s := range statuses {
+		if strings.Contains(c.Cni, "antrea") {
+			_ = patchForAntrea(dockerRuntime, s.Name)
+		}
+		if strings.Contains(c.Cni, "calico") {
+			if err := patchForCalico(dockerRuntime, s.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Prepare downloads the images and binaries minikube needs to create the cluster.
+func (mcm MinikubeClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
+	args, err := minikubeStartArgs(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = minikubeCommand(c, append(args, "--download-only")...)
+	return err
+}
+
+// PreflightCheck performs any pre-checks that can find issues up front that
+// would cause problems for cluster creation.
+func (mcm MinikubeClusterManager) PreflightCheck() ([]string, []error) {
+	cmd := exec.Command("minikube", "version")
+	if err := cmd.Run(); err != nil {
+		return []string{}, []error{fmt.Errorf("minikube is not installed or not on the PATH. Error when attempting to run minikube version: %w", err)}
+	}
+
+	// Clusters are created with the docker driver, so docker must meet the same requirements as for kind
+	return dockerRuntime.PreflightCheck()
+}
+
+// ProviderNotify returns the minikube provider notification used during cluster bootstrapping
+func (mcm MinikubeClusterManager) ProviderNotify() []string {
+	return []string{
+		"Cluster creation using minikube!",
+		"❤️  Checkout this awesome project at https://minikube.sigs.k8s.io",
+	}
+}
+
+// minikubeStartArgs returns the arguments to create a minikube cluster from the configuration.
+func minikubeStartArgs(c *config.UnmanagedClusterConfig) ([]string, error) {
+	cpnc, err := strconv.Atoi(c.ControlPlaneNodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if cpnc != 1 {
+		return nil, fmt.Errorf("the minikube provider supports exactly 1 control plane node")
+	}
+	wnc, err := strconv.Atoi(c.WorkerNodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if wnc < 0 {
+		return nil, fmt.Errorf("cannot have less than 0 worker nodes")
+	}
+
+	// The CNI is installed from the TKR rather than by minikube
+	args := []string{
+		"start",
+		"--profile", c.ClusterName,
+		"--driver", "docker",
+		"--container-runtime", "containerd",
+		"--cni", "false",
+		"--embed-certs",
+		"--nodes", strconv.Itoa(cpnc + wnc),
+	}
+
+	// minikube runs its own base image, so only the Kubernetes version of the node image is used
+	if match := nodeImageVersion.FindStringSubmatch(c.NodeImage); match != nil {
+		args = append(args, "--kubernetes-version", match[1])
+	}
+	if c.PodCidr != "" {
+		args = append(args, "--extra-config", "kubeadm.pod-network-cidr="+c.PodCidr)
+	}
+	if c.ServiceCidr != "" {
+		args = append(args, "--service-cluster-ip-range", c.ServiceCidr)
+	}
+	for _, portToForward := range c.PortsToForward {
+		port := strconv.Itoa(portToForward.ContainerPort)
+		if portToForward.HostPort != 0 {
+			port = fmt.Sprintf("%d:%s", portToForward.HostPort, port)
+		}
+		if portToForward.Protocol != "" {
+			port = fmt.Sprintf("%s/%s", port, portToForward.Protocol)
+		}
+		args = append(args, "--ports", port)
+	}
+
+	return args, nil
+}
+
+// minikubeStatus returns the status of each node of a minikube cluster. No nodes are returned when
+// the cluster does not exist.
+func minikubeStatus(c *config.UnmanagedClusterConfig) ([]minikubeNodeStatus, error) {
+	// minikube exits with a non-zero code when nodes are not running, yet still reports their status
+	output, err := minikubeCommand(c, "status", "--profile", c.ClusterName, "--output", "json")
+	statuses, parseErr := parseMinikubeStatus(output)
+	if parseErr != nil {
+		if err != nil {
+			if strings.Contains(string(output), "not found") {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unable to get status of cluster %s. Error: %s", c.ClusterName, err.Error())
+		}
+		return nil, parseErr
+	}
+	return statuses, nil
+}
+
+// parseMinikubeStatus parses the JSON status of a minikube cluster. minikube reports a single
+// object for clusters with one node, and a list of objects otherwise.
+func parseMinikubeStatus(output []byte) ([]minikubeNodeStatus, error) {
+	var statuses []minikubeNodeStatus
+	trimmed := strings.TrimSpace(string(output))
+	if strings.HasPrefix(trimmed, "{") {
+		status := minikubeNodeStatus{}
+		if err := json.Unmarshal([]byte(trimmed), &status); err != nil {
+			return nil, fmt.Errorf("unable to parse minikube status. Error: %s", err.Error())
+		}
+		statuses = append(statuses, status)
+	} else if err := json.Unmarshal([]byte(trimmed), &statuses); err != nil {
+		return nil, fmt.Errorf("unable to parse minikube status. Error: %s", err.Error())
+	}
+
+	existing := []minikubeNodeStatus{}
+	for _, s := range statuses {
+		if s.Host != minikubeHostNonexistent {
+			existing = append(existing, s)
+		}
+	}
+	return existing, nil
+}
+
+// minikubeCommand runs minikube with the cluster's kubeconfig, so that the kubeconfig of the
+// cluster is written there rather than to the user's kubeconfig. Its output is returned.
+func minikubeCommand(c *config.UnmanagedClusterConfig, args ...string) ([]byte, error) {
+	cmd := exec.Command("minikube", args...)
+	cmd.SetEnv(append(os.Environ(), "KUBECONFIG="+c.KubeconfigPath)...)
+	return exec.Output(cmd)
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+func TestMinikubeStartArgs(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		NodeImage:             "projects.registry.vmware.com/tce/kind/node:v1.22.5",
+		ControlPlaneNodeCount: "1",
+		WorkerNodeCount:       "2",
+		PodCidr:               "10.244.0.0/16",
+		ServiceCidr:           "10.96.0.0/16",
+		PortsToForward: []config.PortMap{
+			{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+			{ContainerPort: 443},
+		},
+	}
+
+	args, err := minikubeStartArgs(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "start --profile test --driver docker --container-runtime containerd --cni false --embed-certs --nodes 3 " +
+		"--kubernetes-version v1.22.5 --extra-config kubeadm.pod-network-cidr=10.244.0.0/16 " +
+		"--service-cluster-ip-range 10.96.0.0/16 --ports 8080:80/tcp --ports 443"
+	if joined := strings.Join(args, " "); joined != expected {
+		t.Errorf("unexpected arguments: %s", joined)
+	}
+}
+
+func TestMinikubeStartArgsMultipleControlPlanes(t *testing.T) {
+	c := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		ControlPlaneNodeCount: "3",
+		WorkerNodeCount:       "1",
+	}
+
+	_, err := minikubeStartArgs(c)
+	if err == nil {
+		t.Error("expected an error for multiple control plane nodes")
+	}
+}
+
+func TestParseMinikubeStatusSingleNode(t *testing.T) {
+	output := `{"Name":"test","Host":"Stopped","Kubelet":"Stopped","APIServer":"Stopped","Kubeconfig":"Stopped","Worker":false}`
+
+	statuses, err := parseMinikubeStatus([]byte(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 node but %d returned", len(statuses))
+	}
+	if statuses[0].Name != "test" || statuses[0].Host != "Stopped" || statuses[0].Worker {
+		t.Errorf("unexpected status %v", statuses[0])
+	}
+}
+
+func TestParseMinikubeStatusMultipleNodes(t *testing.T) {
+	output := `[{"Name":"test","Host":"Running","Worker":false},{"Name":"test-m02","Host":"Running","Worker":true}]`
+
+	statuses, err := parseMinikubeStatus([]byte(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 nodes but %d returned", len(statuses))
+	}
+	if statuses[1].Name != "test-m02" || !statuses[1].Worker {
+		t.Errorf("unexpected status %v", statuses[1])
+	}
+}
+
+func TestParseMinikubeStatusNonexistent(t *testing.T) {
+	output := `{"Name":"test","Host":"Nonexistent","Worker":false}`
+
+	statuses, err := parseMinikubeStatus([]byte(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("expected no nodes but %d returned", len(statuses))
+	}
+}
@@ -4,7 +4,15 @@
 package cluster
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
 )
@@ -27,9 +35,42 @@ func (ncm NoopClusterManager) Create(c *config.UnmanagedClusterConfig) (*Kuberne
 	return kc, nil
 }
 
-// Get retrieves cluster information or return an error indicating a problem.
-func (ncm NoopClusterManager) Get(clusterName string) (*KubernetesCluster, error) {
-	return nil, nil
+// Get retrieves the kubeconfig of the existing cluster. As the cluster is not managed by a
+// provider, its nodes and state are read from the Kubernetes API.
+func (ncm NoopClusterManager) Get(c *config.UnmanagedClusterConfig) (*KubernetesCluster, error) {
+	kcBytes, err := os.ReadFile(c.KubeconfigPath)
+	if os.IsNotExist(err) {
+		return &KubernetesCluster{
+			Name:   c.ClusterName,
+			Status: StatusMissing,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kc := &KubernetesCluster{
+		Name:       c.ClusterName,
+		Kubeconfig: kcBytes,
+		Status:     StatusUnknown,
+		Endpoint:   endpointFromKubeconfig(kcBytes),
+	}
+
+	nodes, err := listKubernetesNodes(kcBytes)
+	if err != nil {
+		// The cluster may not be reachable, but what is known of it is still returned
+		return kc, nil
+	}
+	kc.Status = StatusRunning
+	for _, n := range nodes {
+		if n.Status != nodeReady {
+			kc.Status = StatusStopped
+		}
+	}
+	kc.Nodes = nodes
+	kc.setCreatedAt()
+
+	return kc, nil
 }
 
 // Delete for noop does nothing since these clusters have no provider and are not lifecycled
@@ -37,6 +78,16 @@ func (ncm NoopClusterManager) Delete(c *config.UnmanagedClusterConfig) error {
 	return nil
 }
 
+// Stop is not supported for clusters that are not managed by a provider.
+func (ncm NoopClusterManager) Stop(c *config.UnmanagedClusterConfig) error {
+	return fmt.Errorf("stopping clusters is not supported by the %q provider", NoneClusterManagerProvider)
+}
+
+// Start is not supported for clusters that are not managed by a provider.
+func (ncm NoopClusterManager) Start(c *config.UnmanagedClusterConfig) error {
+	return fmt.Errorf("starting clusters is not supported by the %q provider", NoneClusterManagerProvider)
+}
+
 // Prepare doesn't perform any preparation steps before cluster creation.
 func (ncm NoopClusterManager) Prepare(c *config.UnmanagedClusterConfig) error {
 	return nil
@@ -52,3 +103,51 @@ func (ncm NoopClusterManager) PreflightCheck() ([]string, []error) {
 func (ncm NoopClusterManager) ProviderNotify() []string {
 	return []string{}
 }
+
+const (
+	nodeReady           = "Ready"
+	nodeNotReady        = "NotReady"
+	controlPlaneRoleKey = "node-role.kubernetes.io/control-plane"
+	nodeListTimeout     = 10 * time.Second
+)
+
+// listKubernetesNodes returns the nodes registered with the cluster's API server. Nodes are
+// reported as Ready or NotReady, based on their Ready condition.
+func listKubernetesNodes(kcBytes []byte) ([]Node, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kcBytes)
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = nodeListTimeout
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := clientSet.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{}
+	for i := range nodeList.Items {
+		n := nodeList.Items[i]
+		node := Node{
+			Name:      n.Name,
+			Role:      WorkerRole,
+			Status:    nodeNotReady,
+			CreatedAt: n.CreationTimestamp.Time,
+		}
+		if _, ok := n.Labels[controlPlaneRoleKey]; ok {
+			node.Role = ControlPlaneRole
+		}
+		for _, condition := range n.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				node.Status = nodeReady
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
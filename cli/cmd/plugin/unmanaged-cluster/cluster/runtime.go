@@ -0,0 +1,343 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	kindcluster "sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+const (
+	// DockerRuntime runs the nodes of kind clusters as docker containers.
+	DockerRuntime = "docker"
+	// PodmanRuntime runs the nodes of kind clusters as podman containers.
+	PodmanRuntime = "podman"
+
+	// kindProviderEnvVar selects the runtime of the kind CLI. It is respected so clusters are
+	// created with the same runtime kind itself would use.
+	kindProviderEnvVar = "KIND_EXPERIMENTAL_PROVIDER"
+	// kindNetwork is the network kind attaches node containers to by default.
+	kindNetwork = "kind"
+
+	// rootlessDocsURL documents the host setup kind needs for rootless runtimes.
+	rootlessDocsURL = "https://kind.sigs.k8s.io/docs/user/rootless/"
+)
+
+// containerRuntimes are the names of all supported container runtimes.
+var containerRuntimes = []string{DockerRuntime, PodmanRuntime}
+
+// dockerRuntime runs the nodes of the providers that only support docker.
+var dockerRuntime = NewContainerRuntime(DockerRuntime)
+
+// rootlessCgroupControllers are the cgroup controllers that must be delegated to the user for
+// kind nodes to run with a rootless runtime.
+var rootlessCgroupControllers = []string{"cpu", "memory", "pids"}
+
+// ContainerRuntime runs the node containers of kind clusters. All commands against node
+// containers and their images go through it.
+type ContainerRuntime struct {
+	// Name is the runtime's CLI, either docker or podman.
+	Name string
+}
+
+// NewContainerRuntime gets the container runtime with the name. docker is used when no name is
+// set, as for clusters created before other runtimes were supported.
+func NewContainerRuntime(name string) ContainerRuntime {
+	if name == "" {
+		name = DockerRuntime
+	}
+	return ContainerRuntime{Name: name}
+}
+
+// ValidateContainerRuntime returns an error if the container runtime is not supported.
+func ValidateContainerRuntime(name string) error {
+	for _, r := range containerRuntimes {
+		if name == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown container runtime %q, expected one of: %s", name, strings.Join(containerRuntimes, ", "))
+}
+
+// DetectContainerRuntime returns the name of the runtime to create kind clusters with. As with
+// kind, KIND_EXPERIMENTAL_PROVIDER is respected, and docker is preferred when both runtimes are
+// installed. docker is returned when neither is, so its preflight checks report it missing.
+func DetectContainerRuntime() string {
+	if name := os.Getenv(kindProviderEnvVar); name != "" {
+		return name
+	}
+	if !runtimeAvailable(DockerRuntime) && runtimeAvailable(PodmanRuntime) {
+		return PodmanRuntime
+	}
+	return DockerRuntime
+}
+
+// runtimeAvailable returns whether the runtime's CLI is installed. The version is checked, as
+// podman may be installed with a docker CLI that emulates docker.
+func runtimeAvailable(name string) bool {
+	lines, err := exec.OutputLines(exec.Command(name, "-v"))
+	if err != nil || len(lines) == 0 {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(lines[0]), name+" version")
+}
+
+// kindProvider returns a kind provider that creates nodes with the runtime.
+func (r ContainerRuntime) kindProvider() *kindcluster.Provider {
+	if r.Name == PodmanRuntime {
+		return kindcluster.NewProvider(kindcluster.ProviderWithPodman())
+	}
+	return kindcluster.NewProvider(kindcluster.ProviderWithDocker())
+}
+
+// kindNetworkName returns the name of the network kind attaches node containers to. kind lets
+// it be overridden per runtime through KIND_EXPERIMENTAL_DOCKER_NETWORK or
+// KIND_EXPERIMENTAL_PODMAN_NETWORK.
+func (r ContainerRuntime) kindNetworkName() string {
+	if network := os.Getenv(r.kindNetworkEnvVar()); network != "" {
+		return network
+	}
+	return kindNetwork
+}
+
+// kindNetworkEnvVar returns the environment variable that overrides the network kind attaches
+// node containers of the runtime to.
+func (r ContainerRuntime) kindNetworkEnvVar() string {
+	return "KIND_EXPERIMENTAL_" + strings.ToUpper(r.Name) + "_NETWORK"
+}
+
+// Command returns a command that runs the runtime's CLI.
+func (r ContainerRuntime) Command(args ...string) exec.Cmd {
+	return exec.Command(r.Name, args...)
+}
+
+// Pull fetches an image to the host.
+func (r ContainerRuntime) Pull(image string) error {
+	_, err := exec.Output(r.Command("pull", image))
+	return err
+}
+
+// ImageExists returns whether the image is present on the host.
+func (r ContainerRuntime) ImageExists(image string) bool {
+	_, err := exec.Output(r.Command("image", "inspect", image))
+	return err == nil
+}
+
+// Inspect returns the JSON description of a container.
+func (r ContainerRuntime) Inspect(container string) ([]byte, error) {
+	return exec.Output(r.Command("inspect", "--format", "{{ json . }}", container))
+}
+
+// Exec runs a command in a running container and returns its output.
+func (r ContainerRuntime) Exec(container string, args ...string) ([]byte, error) {
+	return exec.Output(r.Command(append([]string{"exec", container}, args...)...))
+}
+
+// Stop stops containers, keeping them so they can be started again.
+func (r ContainerRuntime) Stop(containers ...string) error {
+	_, err := exec.Output(r.Command(append([]string{"stop"}, containers...)...))
+	return err
+}
+
+// Start starts stopped containers.
+func (r ContainerRuntime) Start(containers ...string) error {
+	_, err := exec.Output(r.Command(append([]string{"start"}, containers...)...))
+	return err
+}
+
+// PreflightCheck verifies the runtime is reachable and that the host it reports on has enough
+// resources, and is set up to run kind nodes when the runtime is rootless.
+func (r ContainerRuntime) PreflightCheck() ([]string, []error) {
+	// Check presence of the runtime
+	cmd := r.Command("ps")
+	if err := cmd.Run(); err != nil {
+		// In this case we can't check the rest of the settings, so just return
+		// the one error.
+		return []string{}, []error{fmt.Errorf("%s is not installed or not reachable. Verify it's installed, running, and your user has permissions to interact with it. Error when attempting to run %s ps: %w", r.Name, r.Name, err)}
+	}
+
+	if r.Name == PodmanRuntime {
+		output, err := exec.Output(r.Command("info", "--format", "json"))
+		if err != nil {
+			return []string{}, []error{fmt.Errorf("unable to get podman info: %w", err)}
+		}
+		return validatePodmanInfo(output)
+	}
+
+	output, err := exec.Output(r.Command("info", "--format", "{{ json . }}"))
+	if err != nil {
+		return []string{}, []error{fmt.Errorf("unable to get docker info: %w", err)}
+	}
+	return validateDockerInfo(output)
+}
+
+// CheckIPv6 verifies the docker network kind attaches node containers to can give them IPv6
+// addresses. kind creates the network with IPv6 enabled, so only a network that already exists
+// without it, such as one created by an older kind release, is a problem. podman networks created
+// by kind are always dual-stack.
+func (r ContainerRuntime) CheckIPv6() error {
+	if r.Name != DockerRuntime {
+		return nil
+	}
+	network := r.kindNetworkName()
+	lines, err := exec.OutputLines(r.Command("network", "ls", "--filter", "name=^"+network+"$", "--format", "{{ .IPv6 }}"))
+	if err != nil {
+		return fmt.Errorf("unable to check whether docker network %s has IPv6 enabled: %w", network, err)
+	}
+	if len(lines) != 0 && strings.TrimSpace(lines[0]) != "true" {
+		return fmt.Errorf("docker network %s does not have IPv6 enabled. Remove it with 'docker network rm %s' so kind recreates it with IPv6", network, network)
+	}
+	return nil
+}
+
+// runtimeInfo is what a container runtime reports about its host that preflight checks validate.
+type runtimeInfo struct {
+	runtime      string
+	cpus         int
+	memory       int64
+	architecture string
+	// cgroupVersion is either 1 or 2, or empty when it is not reported.
+	cgroupVersion string
+	rootless      bool
+	// cgroupControllers are the cgroup controllers available to containers.
+	cgroupControllers []string
+}
+
+type dockerInfo struct {
+	CPUs            int      `json:"NCPU"`
+	Memory          int64    `json:"MemTotal"`
+	Architecture    string   `json:"Architecture"`
+	CgroupVersion   string   `json:"CgroupVersion"`
+	SecurityOptions []string `json:"SecurityOptions"`
+	CPUShares       bool     `json:"CPUShares"`
+	MemoryLimit     bool     `json:"MemoryLimit"`
+	PidsLimit       bool     `json:"PidsLimit"`
+}
+
+func validateDockerInfo(output []byte) ([]string, []error) {
+	info := dockerInfo{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		// Nothing else we can check, just return this error right away
+		return nil, []error{errors.New("unable to parse Docker information")}
+	}
+
+	ri := runtimeInfo{
+		runtime:       DockerRuntime,
+		cpus:          info.CPUs,
+		memory:        info.Memory,
+		architecture:  info.Architecture,
+		cgroupVersion: info.CgroupVersion,
+	}
+	for _, option := range info.SecurityOptions {
+		if option == "name=rootless" {
+			ri.rootless = true
+		}
+	}
+	// docker reports which limits it can apply rather than the controllers themselves
+	limits := map[string]bool{"cpu": info.CPUShares, "memory": info.MemoryLimit, "pids": info.PidsLimit}
+	for _, controller := range rootlessCgroupControllers {
+		if limits[controller] {
+			ri.cgroupControllers = append(ri.cgroupControllers, controller)
+		}
+	}
+
+	return validateRuntimeInfo(&ri)
+}
+
+type podmanInfo struct {
+	Host struct {
+		Arch              string   `json:"arch"`
+		CPUs              int      `json:"cpus"`
+		MemTotal          int64    `json:"memTotal"`
+		CgroupVersion     string   `json:"cgroupVersion"`
+		CgroupControllers []string `json:"cgroupControllers"`
+		Security          struct {
+			Rootless bool `json:"rootless"`
+		} `json:"security"`
+	} `json:"host"`
+}
+
+func validatePodmanInfo(output []byte) ([]string, []error) {
+	info := podmanInfo{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, []error{errors.New("unable to parse Podman information")}
+	}
+
+	ri := runtimeInfo{
+		runtime:           PodmanRuntime,
+		cpus:              info.Host.CPUs,
+		memory:            info.Host.MemTotal,
+		architecture:      info.Host.Arch,
+		cgroupVersion:     strings.TrimPrefix(info.Host.CgroupVersion, "v"),
+		rootless:          info.Host.Security.Rootless,
+		cgroupControllers: info.Host.CgroupControllers,
+	}
+
+	return validateRuntimeInfo(&ri)
+}
+
+func validateRuntimeInfo(info *runtimeInfo) ([]string, []error) {
+	warnings := []string{}
+	issues := []error{}
+
+	// docker reports the kernel's architecture names, podman reports Go's
+	switch info.architecture {
+	case "x86_64", "amd64":
+	case "aarch64", "arm64":
+		// Only amd64 supported right now, arm is experimental.
+		warnings = append(warnings, "Arm64 architecture detected. Support is currently experimental. Some packages may not install due to their arm64 image not being available. You can find a list of package that have arm support in the release notes at https://github.com/vmware-tanzu/community-edition/releases/tag/v0.11.0.")
+	default:
+		// Anything else is not supported.
+		return []string{}, []error{errors.New("only amd64 and arm64 (experimental) architectures are currently supported")}
+	}
+
+	if info.cpus < minCPUCount {
+		// Should only hit this if there is an issue getting the runtime info
+		// correctly, but we can also raise this if we find the need
+		issues = append(issues, fmt.Errorf("minimum %d CPU core is required", minCPUCount))
+	}
+
+	if info.memory < minMemoryBytes {
+		issues = append(issues, fmt.Errorf("minimum %d GiB of memory is required", (minMemoryBytes/1024/1024/1024)))
+	}
+
+	if info.rootless {
+		issues = append(issues, validateRootless(info)...)
+	}
+
+	return warnings, issues
+}
+
+// validateRootless checks that the host lets a rootless runtime run kind nodes, which need cgroup
+// v2 with the cpu, memory and pids controllers delegated to the user.
+func validateRootless(info *runtimeInfo) []error {
+	if info.cgroupVersion != "2" {
+		return []error{fmt.Errorf("rootless %s requires cgroup v2, see %s", info.runtime, rootlessDocsURL)}
+	}
+
+	missing := []string{}
+	for _, required := range rootlessCgroupControllers {
+		found := false
+		for _, controller := range info.cgroupControllers {
+			if controller == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return []error{fmt.Errorf("rootless %s cannot use the %s cgroup controllers, which must be delegated to your user, see %s",
+			info.runtime, strings.Join(missing, ", "), rootlessDocsURL)}
+	}
+	return nil
+}
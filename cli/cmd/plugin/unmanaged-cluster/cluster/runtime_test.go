@@ -0,0 +1,107 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var rootlessPodmanInfoJSON = `{"host":{"arch":"amd64","buildahVersion":"1.23.1","cgroupManager":"systemd","cgroupVersion":"v2","cgroupControllers":["cpu","io","memory","pids"],"cpus":8,"distribution":{"distribution":"fedora","version":"35"},"memTotal":16523735040,"os":"linux","security":{"rootless":true,"seccompEnabled":true,"selinuxEnabled":true}},"version":{"Version":"3.4.4"}}`
+
+func TestValidateContainerRuntime(t *testing.T) {
+	for _, r := range []string{"docker", "podman"} {
+		if err := ValidateContainerRuntime(r); err != nil {
+			t.Errorf("expected container runtime %s to be valid. Error: %s", r, err)
+		}
+	}
+
+	if err := ValidateContainerRuntime("containerd"); err == nil {
+		t.Error("expected an error for an unknown container runtime")
+	}
+}
+
+func TestValidatePodmanInfoRootless(t *testing.T) {
+	warnings, errs := validatePodmanInfo([]byte(rootlessPodmanInfoJSON))
+	if len(warnings) > 0 {
+		t.Errorf("no warnings should be detected but %d returned", len(warnings))
+	}
+	if len(errs) > 0 {
+		t.Errorf("no errors should be detected but %d returned: %v", len(errs), errs)
+	}
+}
+
+func TestValidatePodmanInfoRootlessCgroupV1(t *testing.T) {
+	output := strings.Replace(rootlessPodmanInfoJSON, `"cgroupVersion":"v2"`, `"cgroupVersion":"v1"`, 1)
+
+	_, errs := validatePodmanInfo([]byte(output))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cgroup v2") {
+		t.Errorf("expected a cgroup v2 error but got: %v", errs)
+	}
+}
+
+func TestValidatePodmanInfoRootlessMissingControllers(t *testing.T) {
+	output := strings.Replace(rootlessPodmanInfoJSON, `["cpu","io","memory","pids"]`, `["memory","pids"]`, 1)
+
+	_, errs := validatePodmanInfo([]byte(output))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cpu cgroup controllers") {
+		t.Errorf("expected a missing cpu controller error but got: %v", errs)
+	}
+}
+
+func TestValidatePodmanInfoBadData(t *testing.T) {
+	_, errs := validatePodmanInfo([]byte("podman"))
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error but %d returned", len(errs))
+	}
+}
+
+func TestValidateDockerInfoRootless(t *testing.T) {
+	testInfo := dockerInfo{
+		CPUs:            4,
+		Memory:          8589934592,
+		Architecture:    "x86_64",
+		CgroupVersion:   "2",
+		SecurityOptions: []string{"name=seccomp,profile=default", "name=rootless", "name=cgroupns"},
+		MemoryLimit:     true,
+		PidsLimit:       true,
+	}
+	output, _ := json.Marshal(testInfo)
+	_, errs := validateDockerInfo(output)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cpu cgroup controllers") {
+		t.Errorf("expected a missing cpu controller error but got: %v", errs)
+	}
+
+	testInfo.CPUShares = true
+	output, _ = json.Marshal(testInfo)
+	_, errs = validateDockerInfo(output)
+	if len(errs) != 0 {
+		t.Errorf("no errors expected but %d returned: %v", len(errs), errs)
+	}
+}
+
+func TestKindNetworkName(t *testing.T) {
+	docker := NewContainerRuntime(DockerRuntime)
+	podman := NewContainerRuntime(PodmanRuntime)
+
+	t.Setenv("KIND_EXPERIMENTAL_DOCKER_NETWORK", "")
+	t.Setenv("KIND_EXPERIMENTAL_PODMAN_NETWORK", "")
+	if network := docker.kindNetworkName(); network != kindNetwork {
+		t.Errorf("expected the %s network, got %s", kindNetwork, network)
+	}
+
+	t.Setenv("KIND_EXPERIMENTAL_DOCKER_NETWORK", "kind-registry")
+	if network := docker.kindNetworkName(); network != "kind-registry" {
+		t.Errorf("expected the network set by KIND_EXPERIMENTAL_DOCKER_NETWORK, got %s", network)
+	}
+	if network := podman.kindNetworkName(); network != kindNetwork {
+		t.Errorf("expected podman to ignore KIND_EXPERIMENTAL_DOCKER_NETWORK, got %s", network)
+	}
+
+	t.Setenv("KIND_EXPERIMENTAL_PODMAN_NETWORK", "podman-registry")
+	if network := podman.kindNetworkName(); network != "podman-registry" {
+		t.Errorf("expected the network set by KIND_EXPERIMENTAL_PODMAN_NETWORK, got %s", network)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/bundle"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/registry"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+type bundleExportOpts struct {
+	tkrLocation    string
+	additionalRepo []string
+	registryImage  string
+}
+
+const bundleDesc = `
+Work with bundles, which contain everything required to create an unmanaged
+cluster without access to the registries hosting the Tanzu Kubernetes release
+(TKR) and its packages.`
+
+const bundleExportDesc = `
+Export a bundle to an archive. The bundle contains the TKR BOM, its node image,
+the kapp-controller bundle, the core package repository and any additional
+package repositories, along with the images of all their packages.
+
+Clusters are created from the bundle with:
+
+tanzu unmanaged-cluster create <cluster name> --from-bundle <archive>`
+
+// BundleCmd groups the commands that work with bundles.
+var BundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Work with bundles for creating clusters offline",
+	Long:  bundleDesc,
+}
+
+// BundleExportCmd exports a bundle to an archive.
+var BundleExportCmd = &cobra.Command{
+	Use:   "export <archive>",
+	Short: "Export a bundle for creating clusters offline",
+	Long:  bundleExportDesc,
+	RunE:  exportBundle,
+	Args:  cobra.ExactArgs(1),
+}
+
+var beo = bundleExportOpts{}
+
+func init() {
+	BundleExportCmd.Flags().StringVarP(&beo.tkrLocation, "tkr", "t", config.DefaultTkrLocation(), "The URL to the image containing a Tanzu Kubernetes release")
+	BundleExportCmd.Flags().StringSliceVar(&beo.additionalRepo, "additional-repo", config.DefaultAdditionalPackageRepos(), "Addresses for additional package repositories to export")
+	BundleExportCmd.Flags().StringVar(&beo.registryImage, "registry-image", registry.DefaultImage, "The image of the registry the bundle is served from")
+	BundleExportCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+	BundleCmd.AddCommand(BundleExportCmd)
+}
+
+func exportBundle(cmd *cobra.Command, args []string) error {
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+
+	outputPath, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid archive path %q. Error: %s", args[0], err.Error())
+	}
+
+	log.Eventf(logger.PackageEmoji, "Exporting bundle: %s\n", outputPath)
+	tClient := tanzu.New(log)
+	err = tClient.ExportBundle(&bundle.ExportOptions{
+		TkrLocation:     beo.tkrLocation,
+		AdditionalRepos: beo.additionalRepo,
+		RegistryImage:   beo.registryImage,
+		OutputPath:      outputPath,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export bundle. Error: %s", err.Error())
+	}
+
+	log.Eventf(logger.GreenCheckEmoji, "Exported bundle: %s\n", outputPath)
+	return nil
+}
@@ -30,13 +30,17 @@ var ConfigureCmd = &cobra.Command{
 
 func init() {
 	ConfigureCmd.Flags().StringVarP(&co.clusterConfigFile, "config", "f", "", "Configuration file for unmanaged cluster creation")
+	ConfigureCmd.Flags().StringVar(&co.contextName, "context-name", "", "The name of the cluster's kubeconfig context. Default is the name the provider gives it")
 	ConfigureCmd.Flags().StringVar(&co.infrastructureProvider, "provider", "", "The infrastructure provider to use for cluster creation. Default is 'kind'")
+	ConfigureCmd.Flags().StringVar(&co.containerRuntime, "container-runtime", "", "The container runtime the kind provider runs nodes with, either 'docker' or 'podman'. Default is detected")
 	ConfigureCmd.Flags().StringVarP(&co.tkrLocation, "tkr", "t", "", "The Tanzu Kubernetes Release location.")
 	ConfigureCmd.Flags().StringVarP(&co.cni, "cni", "c", "", "The CNI to deploy. Default is 'antrea'")
 	ConfigureCmd.Flags().StringVar(&co.podcidr, "pod-cidr", "", "The CIDR to use for Pod IP addresses. Default and format is '10.244.0.0/16'")
 	ConfigureCmd.Flags().StringVar(&co.servicecidr, "service-cidr", "", "The CIDR to use for Service IP addresses. Default and format is '10.96.0.0/16'")
+	ConfigureCmd.Flags().StringVar(&co.ipfamily, "ip-family", "", "The IP family of the cluster's networking: ipv4, ipv6 or dual. Default is ipv4")
 	ConfigureCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
 	ConfigureCmd.Flags().StringSliceVar(&co.additionalRepo, "additional-repo", []string{}, "Addresses for additional package repositories to install")
+	addKubernetesFlags(ConfigureCmd.Flags())
 }
 
 func configure(cmd *cobra.Command, args []string) error {
@@ -55,19 +59,33 @@ func configure(cmd *cobra.Command, args []string) error {
 	configArgs := map[string]interface{}{
 		config.ClusterConfigFile:      co.clusterConfigFile,
 		config.ClusterName:            clusterName,
+		config.ContextName:            co.contextName,
 		config.Provider:               co.infrastructureProvider,
+		config.ContainerRuntime:       co.containerRuntime,
 		config.TKRLocation:            co.tkrLocation,
 		config.Cni:                    co.cni,
 		config.PodCIDR:                co.podcidr,
 		config.ServiceCIDR:            co.servicecidr,
+		config.IPFamily:               co.ipfamily,
 		config.AdditionalPackageRepos: co.additionalRepo,
 	}
+	patches, err := readKubeadmConfigPatches(co.kubeadmConfigPatchFiles)
+	if err != nil {
+		log.Errorf("Failed to initialize configuration. Error: %s\n", err.Error())
+		return nil
+	}
+	configArgs[config.KubeadmConfigPatches] = patches
 
 	scConfig, err := config.InitializeConfiguration(configArgs)
 	if err != nil {
 		log.Errorf("Failed to initialize configuration. Error: %s\n", err.Error())
 		return nil
 	}
+	err = setKubernetesCustomization(scConfig)
+	if err != nil {
+		log.Errorf("Failed to initialize configuration. Error: %s\n", err.Error())
+		return nil
+	}
 	fileName := fmt.Sprintf("%s.yaml", clusterName)
 
 	err = config.RenderConfigToFile(fileName, scConfig)
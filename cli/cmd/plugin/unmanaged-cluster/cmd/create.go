@@ -5,30 +5,55 @@
 package cmd
 
 import (
+	"context"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/events"
 	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
 )
 
 type createUnmanagedOpts struct {
 	skipPreflightChecks       bool
+	resume                    bool
+	rollbackOnFailure         bool
+	localRegistry             bool
+	schedulableControlPlanes  bool
+	noSwitchContext           bool
 	clusterConfigFile         string
 	existingClusterKubeconfig string
+	contextName               string
 	infrastructureProvider    string
+	containerRuntime          string
 	tkrLocation               string
 	additionalRepo            []string
 	cni                       string
 	podcidr                   string
 	servicecidr               string
+	ipfamily                  string
 	portMapping               []string
 	numContPlanes             string
 	numWorkers                string
+	timeout                   string
+	outputFormat              string
+	eventsFile                string
+	fromBundle                string
+	featureGates              map[string]string
+	runtimeConfig             map[string]string
+	apiServerExtraArgs        []string
+	kubeletExtraArgs          []string
+	kubeadmConfigPatchFiles   []string
 }
 
+const (
+	textOutputFormat = "text"
+	jsonOutputFormat = "json"
+)
+
 const createDesc = `
 Create an unmanaged Tanzu cluster. This sets up a Kubernetes cluster and installs 
 Tanzu packages. Once the environment is bootstrapped, your kubectl context is 
@@ -45,6 +70,29 @@ When create is called, it respects the following precedence for all configuratio
 3. config file
 4. defaults (least respected)
 
+If creation fails part way through, it can be continued by running create with
+the --resume flag and the name of the cluster. Phases of the bootstrap that
+already completed are skipped and the configuration saved during the failed
+create is used.
+
+Alternatively, the --rollback-on-failure flag deletes a cluster that fails to
+bootstrap, along with its directory and kubeconfig context. Its bootstrap logs
+are kept in $HOME/.config/tanzu/tkg/unmanaged/failures.
+
+Progress can be followed programmatically with --output json, which writes one
+JSON event per line to stdout as each bootstrap phase starts and finishes. Log
+messages are then written to stderr. Events can also be appended to a file with
+--events-file.
+
+Packages listed under Packages in the configuration file are installed once the
+package repositories have reconciled. Each package install must reconcile within
+PackageTimeout.
+
+Clusters can be created without network access from a bundle exported with
+tanzu unmanaged-cluster bundle export, by passing the archive to --from-bundle.
+The bundle's images are served to the cluster from a registry container started
+alongside it.
+
 Exit codes are provided to enhance the automation of bootstrapping and are defined as follows:
 
 0  - Success.
@@ -60,7 +108,13 @@ Exit codes are provided to enhance the automation of bootstrapping and are defin
 10 - Could not install core package repo to cluster.
 11 - Could not install additional package repo
 12 - Could not install CNI package.
-13 - Failed to merge kubeconfig and set context`
+13 - Failed to merge kubeconfig and set context
+14 - Could not read the state of a cluster to resume its creation
+15 - Bootstrapping did not complete within the configured timeout
+16 - Timed out waiting for kapp controller to be running
+17 - Timed out waiting for the core package repo to reconcile
+18 - Could not install a package from the config, or it did not reconcile
+19 - Could not import the bundle the cluster is created from`
 
 // CreateCmd creates an unmanaged workload cluster.
 var CreateCmd = &cobra.Command{
@@ -76,17 +130,30 @@ var co = createUnmanagedOpts{}
 func init() {
 	CreateCmd.Flags().StringVarP(&co.clusterConfigFile, "config", "f", "", "A config file describing how to create the Tanzu environment")
 	CreateCmd.Flags().StringVarP(&co.existingClusterKubeconfig, "existing-cluster-kubeconfig", "e", "", "Use an existing kubeconfig to tanzu-ify a cluster")
+	CreateCmd.Flags().StringVar(&co.contextName, "context-name", "", "The name of the cluster's kubeconfig context; default is the name the provider gives it")
+	CreateCmd.Flags().BoolVar(&co.noSwitchContext, "no-switch-context", false, "Add the cluster's context to the kubeconfig without making it the current context; default is false")
 	CreateCmd.Flags().StringVar(&co.infrastructureProvider, "provider", "", "The infrastructure provider for cluster creation; default is kind")
+	CreateCmd.Flags().StringVar(&co.containerRuntime, "container-runtime", "", "The container runtime the kind provider runs nodes with (docker|podman); default is detected")
 	CreateCmd.Flags().StringVarP(&co.tkrLocation, "tkr", "t", "", "The URL to the image containing a Tanzu Kubernetes release")
 	CreateCmd.Flags().StringSliceVar(&co.additionalRepo, "additional-repo", []string{}, "Addresses for additional package repositories to install")
 	CreateCmd.Flags().StringVarP(&co.cni, "cni", "c", "", "The CNI to deploy; default is antrea")
 	CreateCmd.Flags().StringVar(&co.podcidr, "pod-cidr", "", "The CIDR for Pod IP allocation; default is 10.244.0.0/16")
 	CreateCmd.Flags().StringVar(&co.servicecidr, "service-cidr", "", "The CIDR for Service IP allocation; default is 10.96.0.0/16")
+	CreateCmd.Flags().StringVar(&co.ipfamily, "ip-family", "", "The IP family of the cluster's networking: ipv4, ipv6 or dual; default is ipv4")
 	CreateCmd.Flags().StringSliceVarP(&co.portMapping, "port-map", "p", []string{}, "Ports to map between container node and the host (format: '80:80/tcp' or just '80')")
 	CreateCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
 	CreateCmd.Flags().BoolVar(&co.skipPreflightChecks, "skip-preflight", false, "Skip the preflight checks; default is false")
 	CreateCmd.Flags().StringVar(&co.numContPlanes, "control-plane-node-count", "", "The number of control plane nodes to deploy; default is 1")
 	CreateCmd.Flags().StringVar(&co.numWorkers, "worker-node-count", "", "The number of worker nodes to deploy; default is 0")
+	CreateCmd.Flags().BoolVar(&co.resume, "resume", false, "Resume creation of a cluster that previously failed to bootstrap; default is false")
+	CreateCmd.Flags().StringVar(&co.timeout, "timeout", "", "The maximum duration to bootstrap the cluster (format: '30m'); default is no timeout")
+	CreateCmd.Flags().StringVarP(&co.outputFormat, "output", "o", textOutputFormat, "Output format (text|json); json writes bootstrap progress events to stdout")
+	CreateCmd.Flags().StringVar(&co.eventsFile, "events-file", "", "A file to append JSON bootstrap progress events to")
+	CreateCmd.Flags().BoolVar(&co.rollbackOnFailure, "rollback-on-failure", false, "Delete the cluster and its directory if bootstrapping fails; default is false")
+	CreateCmd.Flags().BoolVar(&co.localRegistry, "local-registry", false, "Start a registry alongside the cluster that its nodes pull images through; default is false")
+	CreateCmd.Flags().BoolVar(&co.schedulableControlPlanes, "schedulable-control-planes", false, "Remove the NoSchedule taints of control plane nodes so workloads run on them; default is false unless there are multiple control planes and no workers")
+	CreateCmd.Flags().StringVar(&co.fromBundle, "from-bundle", "", "A bundle archive, created by bundle export, to create the cluster from without network access")
+	addKubernetesFlags(CreateCmd.Flags())
 }
 
 func create(cmd *cobra.Command, args []string) {
@@ -103,6 +170,49 @@ func create(cmd *cobra.Command, args []string) {
 	// initial logger, needed for logging if something goes wrong
 	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
 
+	// When events are written to stdout, log messages are moved to stderr so
+	// the event stream can be parsed
+	var eventWriters []io.Writer
+	switch co.outputFormat {
+	case textOutputFormat:
+	case jsonOutputFormat:
+		log = logger.NewLoggerWithOutput(TtySetting(cmd.Flags()), 0, os.Stderr)
+		eventWriters = append(eventWriters, os.Stdout)
+	default:
+		log.Errorf("invalid output format %q, expected text or json\n", co.outputFormat)
+		os.Exit(tanzu.InvalidConfig)
+	}
+	if co.eventsFile != "" {
+		eventsFile, err := os.OpenFile(co.eventsFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Errorf("failed to open events file %q. Error: %v\n", co.eventsFile, err)
+			os.Exit(tanzu.InvalidConfig)
+		}
+		// Events are written unbuffered, the file is closed when the process exits
+		eventWriters = append(eventWriters, eventsFile)
+	}
+	var tmOptions []tanzu.Option
+	if len(eventWriters) > 0 {
+		tmOptions = append(tmOptions, tanzu.WithEventEmitter(events.NewJSONEmitter(io.MultiWriter(eventWriters...))))
+	}
+
+	// Resuming uses the configuration saved by the failed create, so no other
+	// configuration is resolved
+	if co.resume {
+		if clusterName == "" {
+			log.Error("cluster name must be provided to resume cluster creation")
+			os.Exit(tanzu.InvalidConfig)
+		}
+
+		tm := tanzu.New(log, tmOptions...)
+		exitCode, err := tm.Resume(context.Background(), clusterName)
+		if err != nil {
+			log.Error(err.Error())
+			os.Exit(exitCode)
+		}
+		return
+	}
+
 	// Attempt to read cluster name from provided kubeconfig
 	if co.existingClusterKubeconfig != "" {
 		clusterName, err = tanzu.ReadClusterContextFromKubeconfig(co.existingClusterKubeconfig)
@@ -117,21 +227,49 @@ func create(cmd *cobra.Command, args []string) {
 		config.ClusterConfigFile:         co.clusterConfigFile,
 		config.ExistingClusterKubeconfig: co.existingClusterKubeconfig,
 		config.ClusterName:               clusterName,
+		config.ContextName:               co.contextName,
 		config.Provider:                  co.infrastructureProvider,
+		config.ContainerRuntime:          co.containerRuntime,
 		config.TKRLocation:               co.tkrLocation,
 		config.Cni:                       co.cni,
 		config.PodCIDR:                   co.podcidr,
 		config.ServiceCIDR:               co.servicecidr,
+		config.IPFamily:                  co.ipfamily,
 		config.ControlPlaneNodeCount:     co.numContPlanes,
 		config.WorkerNodeCount:           co.numWorkers,
 		config.AdditionalPackageRepos:    co.additionalRepo,
+		config.Timeout:                   co.timeout,
+		config.FromBundle:                co.fromBundle,
+	}
+	patches, err := readKubeadmConfigPatches(co.kubeadmConfigPatchFiles)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(tanzu.InvalidConfig)
 	}
+	configArgs[config.KubeadmConfigPatches] = patches
 	clusterConfig, err := config.InitializeConfiguration(configArgs)
 	if err != nil {
 		log.Errorf("Failed to initialize configuration. Error %v\n", err)
 		os.Exit(tanzu.InvalidConfig)
 	}
+	err = setKubernetesCustomization(clusterConfig)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(tanzu.InvalidConfig)
+	}
 	clusterConfig.SkipPreflightChecks = co.skipPreflightChecks
+	if cmd.Flags().Changed("rollback-on-failure") {
+		clusterConfig.RollbackOnFailure = co.rollbackOnFailure
+	}
+	if cmd.Flags().Changed("local-registry") {
+		clusterConfig.EnableLocalRegistry = co.localRegistry
+	}
+	if cmd.Flags().Changed("schedulable-control-planes") {
+		clusterConfig.SchedulableControlPlanes = co.schedulableControlPlanes
+	}
+	if cmd.Flags().Changed("no-switch-context") {
+		clusterConfig.SkipContextSwitch = co.noSwitchContext
+	}
 
 	// TODO(stmcginnis): For now, we are only supporting port maps from command
 	// line arguments. At some point we need to add env variable and config file
@@ -145,8 +283,8 @@ func create(cmd *cobra.Command, args []string) {
 		clusterConfig.PortsToForward = append(clusterConfig.PortsToForward, mapping)
 	}
 
-	tm := tanzu.New(log)
-	exitCode, err := tm.Deploy(clusterConfig)
+	tm := tanzu.New(log, tmOptions...)
+	exitCode, err := tm.Deploy(context.Background(), clusterConfig)
 	if err != nil {
 		log.Error(err.Error())
 		os.Exit(exitCode)
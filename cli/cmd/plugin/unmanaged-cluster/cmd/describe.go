@@ -0,0 +1,76 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/internal/hack"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+const describeDesc = `
+Describe an unmanaged cluster. This combines the configuration stored in
+$HOME/.config/tanzu/tkg/unmanaged/${CLUSTER_NAME} with the state of the cluster
+reported by its provider and the health of kapp-controller, the core package
+repository and the CNI package.`
+
+type describeUnmanagedOptions struct {
+	outputFormat string
+}
+
+var do = describeUnmanagedOptions{}
+
+// DescribeCmd describes an unmanaged cluster.
+var DescribeCmd = &cobra.Command{
+	Use:   "describe <cluster name>",
+	Short: "Describe an unmanaged cluster",
+	Long:  describeDesc,
+	RunE:  describe,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	DescribeCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+	DescribeCmd.Flags().StringVarP(&do.outputFormat, "output", "o", "table", "Output format (yaml|json|table)")
+}
+
+// describe outputs the details of an unmanaged cluster.
+func describe(cmd *cobra.Command, args []string) error {
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+	tClient := tanzu.New(log)
+	description, err := tClient.Describe(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to describe cluster. Error: %s\n", err.Error()) //nolint:revive,stylecheck
+	}
+
+	if do.outputFormat == string(hack.JSONOutputType) || do.outputFormat == string(hack.YAMLOutputType) {
+		hack.NewObjectWriter(cmd.OutOrStdout(), do.outputFormat, description).Render()
+		return nil
+	}
+
+	t := hack.NewOutputWriter(cmd.OutOrStdout(), string(hack.ListTableOutputType),
+		"NAME", "PROVIDER", "STATUS", "ENDPOINT", "NODE IMAGE", "TKR", "KUBECONFIG", "REGISTRY", "CNI",
+		"KAPP CONTROLLER", "CORE PACKAGE REPO", "CNI PACKAGE")
+	t.AddRow(description.Name, description.Provider, description.Status, description.Endpoint,
+		description.NodeImage, description.Config.TkrLocation, description.KubeconfigPath, description.Registry, description.Config.Cni,
+		description.Health.KappController, description.Health.CorePackageRepo, description.Health.CNI)
+	t.Render()
+
+	if len(description.Nodes) == 0 {
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	nodes := hack.NewOutputWriter(cmd.OutOrStdout(), string(hack.ListTableOutputType), "NODE", "ROLE", "STATUS", "PORTS")
+	for _, n := range description.Nodes {
+		nodes.AddRow(n.Name, n.Role, n.Status, strings.Join(n.Ports, ", "))
+	}
+	nodes.Render()
+
+	return nil
+}
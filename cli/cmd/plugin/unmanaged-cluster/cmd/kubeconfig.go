@@ -0,0 +1,74 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+type kubeconfigGetOpts struct {
+	exportFile string
+}
+
+const kubeconfigDesc = `
+Work with the kubeconfig contexts of unmanaged clusters, which are merged into
+your default kubeconfig when clusters are created.`
+
+const kubeconfigGetDesc = `
+Get a standalone kubeconfig for an unmanaged cluster. It holds only the
+cluster's context, along with the cluster and user it references, taken from
+your default kubeconfig. It is printed unless an export file is set.`
+
+// KubeconfigCmd groups the commands that work with the kubeconfig of clusters.
+var KubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Work with the kubeconfig of unmanaged clusters",
+	Long:  kubeconfigDesc,
+}
+
+// KubeconfigGetCmd gets a standalone kubeconfig for a cluster.
+var KubeconfigGetCmd = &cobra.Command{
+	Use:   "get <cluster name>",
+	Short: "Get a standalone kubeconfig for an unmanaged cluster",
+	Long:  kubeconfigGetDesc,
+	RunE:  getKubeconfig,
+	Args:  cobra.ExactArgs(1),
+}
+
+var kgo = kubeconfigGetOpts{}
+
+func init() {
+	KubeconfigGetCmd.Flags().StringVar(&kgo.exportFile, "export-file", "", "A file to write the kubeconfig to instead of printing it")
+	KubeconfigGetCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+	KubeconfigCmd.AddCommand(KubeconfigGetCmd)
+}
+
+func getKubeconfig(cmd *cobra.Command, args []string) error {
+	clusterName := args[0]
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+
+	tClient := tanzu.New(log)
+	data, err := tClient.GetKubeconfig(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig. Error: %s", err.Error())
+	}
+
+	if kgo.exportFile == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	err = os.WriteFile(kgo.exportFile, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig to %s. Error: %s", kgo.exportFile, err.Error())
+	}
+	log.Eventf(logger.GreenCheckEmoji, "Exported kubeconfig of cluster %s: %s\n", clusterName, kgo.exportFile)
+	return nil
+}
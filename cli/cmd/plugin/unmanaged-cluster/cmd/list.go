@@ -15,7 +15,8 @@ import (
 
 const listDesc = `
 List known unmanaged clusters. This list is produced by locating clusters saved to
-$HOME/.config/tanzu/tkg/unmanaged`
+$HOME/.config/tanzu/tkg/unmanaged. The status of each cluster is retrieved from its
+provider and is one of Running, Stopped, Missing or Unknown.`
 
 type listUnmanagedOptions struct {
 	outputFormat string
@@ -59,9 +60,10 @@ func list(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	t := hack.NewOutputWriter(cmd.OutOrStdout(), lo.outputFormat, "NAME", "PROVIDER")
+	t := hack.NewOutputWriter(cmd.OutOrStdout(), lo.outputFormat, "NAME", "PROVIDER", "STATUS", "KUBERNETES VERSION",
+		"CONTROL PLANES", "WORKERS", "CNI", "CREATED", "CONTEXT")
 	for _, c := range clusters {
-		t.AddRow(c.Name, c.Provider)
+		t.AddRow(c.Name, c.Provider, c.Status, c.KubernetesVersion, c.ControlPlaneNodes, c.WorkerNodes, c.Cni, c.Created, c.Context)
 	}
 	t.Render()
 
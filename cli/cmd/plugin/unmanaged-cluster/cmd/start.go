@@ -0,0 +1,61 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+const startDesc = `
+Start a Tanzu unmanaged cluster that was stopped with tanzu unmanaged-cluster stop.
+Once the cluster's nodes are started, this waits for the API server to respond, all
+nodes to be Ready and kapp-controller to be running.
+
+Starting clusters is not supported for clusters using the none provider.`
+
+type startUnmanagedOptions struct {
+	timeout time.Duration
+}
+
+var so = startUnmanagedOptions{}
+
+// StartCmd starts a stopped unmanaged cluster.
+var StartCmd = &cobra.Command{
+	Use:   "start <cluster name>",
+	Short: "Start a stopped unmanaged cluster",
+	Long:  startDesc,
+	RunE:  start,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	StartCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+	StartCmd.Flags().DurationVar(&so.timeout, "timeout", 5*time.Minute, "The maximum duration to wait for the cluster to be ready")
+}
+
+func start(cmd *cobra.Command, args []string) error {
+	clusterName := args[0]
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), so.timeout)
+	defer cancel()
+
+	log.Eventf(logger.RocketEmoji, "Starting cluster: %s\n", clusterName)
+	tClient := tanzu.New(log)
+	err := tClient.Start(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to start cluster. Error: %s", err.Error())
+	}
+
+	log.Eventf(logger.GreenCheckEmoji, "Started cluster: %s\n", clusterName)
+
+	return nil
+}
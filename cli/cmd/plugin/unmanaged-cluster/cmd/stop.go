@@ -0,0 +1,49 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+const stopDesc = `
+Stop a Tanzu unmanaged cluster. This stops the cluster's nodes to free up the
+resources they use, while keeping the cluster and its state. The cluster can be
+started again with tanzu unmanaged-cluster start <cluster name>.
+
+Stopping clusters is not supported for clusters using the none provider.`
+
+// StopCmd stops an unmanaged cluster.
+var StopCmd = &cobra.Command{
+	Use:   "stop <cluster name>",
+	Short: "Stop an unmanaged cluster",
+	Long:  stopDesc,
+	RunE:  stop,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	StopCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+}
+
+func stop(cmd *cobra.Command, args []string) error {
+	clusterName := args[0]
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+
+	log.Eventf(logger.TestTubeEmoji, "Stopping cluster: %s\n", clusterName)
+	tClient := tanzu.New(log)
+	err := tClient.Stop(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to stop cluster. Error: %s", err.Error())
+	}
+
+	log.Eventf(logger.TestTubeEmoji, "Stopped cluster: %s\n", clusterName)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tanzu"
+)
+
+const upgradeDesc = `
+Upgrade a Tanzu unmanaged cluster to a new Tanzu Kubernetes Release (TKR).
+The new TKR's kapp-controller is applied, the core package repository is
+pointed at the new TKR's and the CNI is moved to the version it provides.
+The cluster's config.yaml is updated to the new TKR.
+
+Nodes are not upgraded. When the new TKR uses a different node image, a
+warning is printed and the cluster must be recreated to use it.
+
+Clusters created from a bundle cannot be upgraded.`
+
+type upgradeUnmanagedOptions struct {
+	tkrLocation string
+	timeout     time.Duration
+}
+
+var uo = upgradeUnmanagedOptions{}
+
+// UpgradeCmd upgrades an unmanaged cluster to a new TKR.
+var UpgradeCmd = &cobra.Command{
+	Use:   "upgrade <cluster name>",
+	Short: "Upgrade an unmanaged cluster to a new TKR",
+	Long:  upgradeDesc,
+	RunE:  upgrade,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	UpgradeCmd.Flags().StringVarP(&uo.tkrLocation, "tkr", "t", "", "The URL to the image containing the new Tanzu Kubernetes release")
+	UpgradeCmd.Flags().Bool("tty-disable", false, "Disable log stylization and emojis")
+	UpgradeCmd.Flags().DurationVar(&uo.timeout, "timeout", 15*time.Minute, "The maximum duration of the upgrade")
+	_ = UpgradeCmd.MarkFlagRequired("tkr")
+}
+
+func upgrade(cmd *cobra.Command, args []string) error {
+	clusterName := args[0]
+	log := logger.NewLogger(TtySetting(cmd.Flags()), 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), uo.timeout)
+	defer cancel()
+
+	log.Eventf(logger.RocketEmoji, "Upgrading cluster %s to TKR: %s\n", clusterName, uo.tkrLocation)
+	tClient := tanzu.New(log)
+	err := tClient.Upgrade(ctx, clusterName, uo.tkrLocation)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade cluster. Error: %s", err.Error())
+	}
+
+	log.Eventf(logger.GreenCheckEmoji, "Upgraded cluster: %s\n", clusterName)
+
+	return nil
+}
@@ -4,11 +4,15 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/pflag"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
 )
 
 // TtySetting gets the setting to use for formatted TTY output based on whether
@@ -46,3 +50,71 @@ func TtySetting(flags *pflag.FlagSet) bool {
 	}
 	return result
 }
+
+// addKubernetesFlags adds the flags customizing the Kubernetes components of the cluster, shared
+// by create and configure.
+func addKubernetesFlags(flags *pflag.FlagSet) {
+	flags.StringToStringVar(&co.featureGates, "feature-gates", nil, "Kubernetes feature gates to enable or disable (format: 'Gate1=true,Gate2=false')")
+	flags.StringToStringVar(&co.runtimeConfig, "runtime-config", nil, "API versions to enable or disable in the API server (format: 'api/alpha=true')")
+	flags.StringArrayVar(&co.apiServerExtraArgs, "apiserver-extra-arg", []string{}, "A flag to pass to the API server, may be repeated (format: 'enable-admission-plugins=NodeRestriction')")
+	flags.StringArrayVar(&co.kubeletExtraArgs, "kubelet-extra-arg", []string{}, "A flag to pass to the kubelet of every node, may be repeated (format: 'max-pods=200')")
+	flags.StringArrayVar(&co.kubeadmConfigPatchFiles, "kubeadm-config-patch", []string{}, "A file containing a merge patch of the kubeadm configuration of every node, may be repeated")
+}
+
+// readKubeadmConfigPatches reads the kubeadm config patches in the files.
+func readKubeadmConfigPatches(paths []string) ([]string, error) {
+	patches := []string{}
+	for _, path := range paths {
+		patch, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubeadm config patch %s. Error: %s", path, err.Error())
+		}
+		patches = append(patches, string(patch))
+	}
+	return patches, nil
+}
+
+// setKubernetesCustomization merges the Kubernetes customizations set by flags over those of the
+// configuration.
+func setKubernetesCustomization(scConfig *config.UnmanagedClusterConfig) error {
+	for gate, value := range co.featureGates {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for feature gate %s, expected true or false", value, gate)
+		}
+		if scConfig.FeatureGates == nil {
+			scConfig.FeatureGates = map[string]bool{}
+		}
+		scConfig.FeatureGates[gate] = enabled
+	}
+	for api, value := range co.runtimeConfig {
+		if scConfig.RuntimeConfig == nil {
+			scConfig.RuntimeConfig = map[string]string{}
+		}
+		scConfig.RuntimeConfig[api] = value
+	}
+
+	var err error
+	scConfig.APIServerExtraArgs, err = mergeExtraArgs(scConfig.APIServerExtraArgs, co.apiServerExtraArgs)
+	if err != nil {
+		return err
+	}
+	scConfig.KubeletExtraArgs, err = mergeExtraArgs(scConfig.KubeletExtraArgs, co.kubeletExtraArgs)
+	return err
+}
+
+// mergeExtraArgs merges flags, in the format name=value, over the arguments of a component.
+func mergeExtraArgs(args map[string]string, flags []string) (map[string]string, error) {
+	for _, flag := range flags {
+		parts := strings.SplitN(flag, "=", 2) //nolint:gomnd
+		name := strings.TrimLeft(parts[0], "-")
+		if len(parts) != 2 || name == "" { //nolint:gomnd
+			return nil, fmt.Errorf("invalid argument %q, expected name=value", flag)
+		}
+		if args == nil {
+			args = map[string]string{}
+		}
+		args[name] = parts[1]
+	}
+	return args, nil
+}
@@ -23,6 +23,7 @@ const (
 	ClusterConfigFile         = "ClusterConfigFile"
 	ExistingClusterKubeconfig = "ExistingClusterKubeconfig"
 	ClusterName               = "ClusterName"
+	ContextName               = "ContextName"
 	Tty                       = "Tty"
 	TKRLocation               = "TkrLocation"
 	AdditionalPackageRepos    = "AdditionalPackageRepos"
@@ -30,6 +31,10 @@ const (
 	Cni                       = "Cni"
 	PodCIDR                   = "PodCidr"
 	ServiceCIDR               = "ServiceCidr"
+	IPFamily                  = "IpFamily"
+	IPFamilyIPv4              = "ipv4"
+	IPFamilyIPv6              = "ipv6"
+	IPFamilyDual              = "dual"
 	configDir                 = ".config"
 	tanzuConfigDir            = "tanzu"
 	tkgConfigDir              = "tkg"
@@ -40,22 +45,63 @@ const (
 	ProtocolSCTP              = "sctp"
 	ControlPlaneNodeCount     = "ControlPlaneNodeCount"
 	WorkerNodeCount           = "WorkerNodeCount"
+	Timeout                   = "Timeout"
+	KappTimeout               = "KappTimeout"
+	RepoTimeout               = "RepoTimeout"
+	PackageTimeout            = "PackageTimeout"
+	FromBundle                = "FromBundle"
+	CACertificates            = "CACertificates"
+	HTTPProxy                 = "HttpProxy"
+	HTTPSProxy                = "HttpsProxy"
+	NoProxy                   = "NoProxy"
+	ContainerRuntime          = "ContainerRuntime"
+	Nodes                     = "Nodes"
+	NodeRoleControlPlane      = "control-plane"
+	NodeRoleWorker            = "worker"
+	FeatureGates              = "FeatureGates"
+	RuntimeConfig             = "RuntimeConfig"
+	APIServerExtraArgs        = "ApiServerExtraArgs"
+	KubeletExtraArgs          = "KubeletExtraArgs"
+	KubeadmConfigPatches      = "KubeadmConfigPatches"
 )
 
+// envExcludedFields are the fields that are not set from environment variables. The values of
+// string slices are split on commas, which the YAML documents of kubeadm config patches contain.
+var envExcludedFields = map[string]bool{
+	KubeadmConfigPatches: true,
+}
+
 var defaultConfigValues = map[string]interface{}{
 	TKRLocation:           "projects.registry.vmware.com/tce/tkr:v0.17.0",
 	Provider:              "kind",
 	Cni:                   "antrea",
-	PodCIDR:               "10.244.0.0/16",
-	ServiceCIDR:           "10.96.0.0/16",
+	PodCIDR:               defaultCIDRs[IPFamilyIPv4][0],
+	ServiceCIDR:           defaultCIDRs[IPFamilyIPv4][1],
+	IPFamily:              IPFamilyIPv4,
 	Tty:                   "true",
 	ControlPlaneNodeCount: "1",
 	WorkerNodeCount:       "0",
+	KappTimeout:           "5m",
+	RepoTimeout:           "10m",
+	PackageTimeout:        "10m",
 	AdditionalPackageRepos: []string{
 		"projects.registry.vmware.com/tce/main:v0.11.0",
 	},
 }
 
+// defaultCIDRs are the default pod and service CIDRs of each IP family.
+var defaultCIDRs = map[string][2]string{
+	IPFamilyIPv4: {"10.244.0.0/16", "10.96.0.0/16"},
+	IPFamilyIPv6: {"fd00:10:244::/56", "fd00:10:96::/112"},
+	IPFamilyDual: {"10.244.0.0/16,fd00:10:244::/56", "10.96.0.0/16,fd00:10:96::/112"},
+}
+
+// DefaultCIDRs returns the default pod and service CIDRs of an IP family.
+func DefaultCIDRs(ipFamily string) (podCidr, serviceCidr string) {
+	cidrs := defaultCIDRs[ipFamily]
+	return cidrs[0], cidrs[1]
+}
+
 // PortMap is the mapping between a host port and a container port.
 type PortMap struct {
 	// HostPort is the port on the host machine.
@@ -66,6 +112,66 @@ type PortMap struct {
 	Protocol string `yaml:"Protocol,omitempty"`
 }
 
+// Package is a package to install into the cluster once the package repositories have reconciled.
+type Package struct {
+	// Name is the fully qualified name of the package (e.g. cert-manager.community.tanzu.vmware.com),
+	// or the first part of it (e.g. cert-manager).
+	Name string `yaml:"Name"`
+	// Version is the version of the package to install, or a semver range (e.g. ">=1.5.0 <2.0.0").
+	// Default is the latest version available.
+	Version string `yaml:"Version,omitempty"`
+	// Namespace is the namespace the package is installed to. It is created if it does not exist.
+	// Default is default
+	Namespace string `yaml:"Namespace,omitempty"`
+	// InstallName is the name of the PackageInstall created for the package.
+	// Default is the first part of the package name (e.g. cert-manager)
+	InstallName string `yaml:"InstallName,omitempty"`
+	// Values is the configuration to install the package with.
+	Values map[string]interface{} `yaml:"Values,omitempty"`
+	// ValuesFile is the path to a file containing the configuration to install the package with.
+	// It cannot be combined with Values.
+	ValuesFile string `yaml:"ValuesFile,omitempty"`
+	// DependsOn lists the install names of other packages that must be installed and reconciled
+	// before this package is installed. Otherwise, packages are installed in the order listed.
+	DependsOn []string `yaml:"DependsOn,omitempty"`
+}
+
+// RegistryMirror is a set of endpoints images of a registry are pulled from instead.
+type RegistryMirror struct {
+	// Registry is the registry host that is mirrored, such as docker.io.
+	Registry string `yaml:"Registry"`
+	// Endpoints are the URLs of the mirrors, tried in order, such as https://mirror.example.com.
+	Endpoints []string `yaml:"Endpoints"`
+}
+
+// Node customizes a single node of the cluster.
+type Node struct {
+	// Role is the role of the node, either control-plane or worker.
+	// Default is control-plane
+	Role string `yaml:"Role,omitempty"`
+	// Labels are added to the node.
+	Labels map[string]string `yaml:"Labels,omitempty"`
+	// Taints are registered with the node, in the format key[=value]:effect (e.g. dedicated=gpu:NoSchedule).
+	// They replace the taints kubeadm registers by default.
+	Taints []string `yaml:"Taints,omitempty"`
+	// ExtraMounts are host paths mounted into the node.
+	ExtraMounts []Mount `yaml:"ExtraMounts,omitempty"`
+	// ExtraPortMappings are host ports mapped to ports of the node.
+	ExtraPortMappings []PortMap `yaml:"ExtraPortMappings,omitempty"`
+	// KubeadmConfigPatches are merge patches applied to the node's kubeadm configuration.
+	KubeadmConfigPatches []string `yaml:"KubeadmConfigPatches,omitempty"`
+}
+
+// Mount is a host path mounted into a node.
+type Mount struct {
+	// HostPath is the path on the host machine.
+	HostPath string `yaml:"HostPath"`
+	// ContainerPath is the path in the node.
+	ContainerPath string `yaml:"ContainerPath"`
+	// ReadOnly determines whether the mount is read-only.
+	ReadOnly bool `yaml:"ReadOnly,omitempty"`
+}
+
 // UnmanagedClusterConfig contains all the configuration settings for creating a
 // unmanaged Tanzu cluster.
 type UnmanagedClusterConfig struct {
@@ -74,6 +180,12 @@ type UnmanagedClusterConfig struct {
 	// KubeconfigPath is the location where the Kubeconfig will be persisted
 	// after the cluster is created.
 	KubeconfigPath string `yaml:"KubeconfigPath"`
+	// ContextName is the name of the cluster's context in the kubeconfig. Default is the name the
+	// provider gives it (e.g. kind-<ClusterName>). It cannot be set for existing clusters.
+	ContextName string `yaml:"ContextName"`
+	// SkipContextSwitch determines whether the current context is left unchanged when the cluster's
+	// context is merged into the default kubeconfig.
+	SkipContextSwitch bool `yaml:"SkipContextSwitch"`
 	// ExistingClusterKubeconfig is the serialized path to the kubeconfig to use of an existing cluster.
 	ExistingClusterKubeconfig string `yaml:"ExistingClusterKubeconfig"`
 	// NodeImage is the host OS image to use for Kubernetes nodes.
@@ -85,15 +197,24 @@ type UnmanagedClusterConfig struct {
 	// ProviderConfiguration offers optional provider-specific configuration.
 	// The exact keys and values accepted are determined by the provider.
 	ProviderConfiguration map[string]interface{} `yaml:"ProviderConfiguration"`
+	// ContainerRuntime is the container runtime the kind provider runs nodes with (docker or podman).
+	// Default is detected, preferring docker when both are installed.
+	ContainerRuntime string `yaml:"ContainerRuntime"`
 	// CNI is the networking CNI to use in the cluster. Default is calico.
 	Cni string `yaml:"Cni"`
-	// CNIConfiguration offers optional cni-plugin specific configuration.
-	// The exact keys and values accepted are determined by the CNI choice.
+	// CNIConfiguration offers optional cni-plugin specific configuration. It is merged over
+	// the provider defaults and used as the values of the CNI package install.
+	// The exact keys and values accepted are determined by the values schema of the CNI package.
 	CNIConfiguration map[string]interface{} `yaml:"CniConfiguration"`
-	// PodCidr is the Pod CIDR range to assign pod IP addresses.
+	// PodCidr is the Pod CIDR range to assign pod IP addresses. Dual-stack clusters take an IPv4
+	// and an IPv6 CIDR, separated by a comma.
 	PodCidr string `yaml:"PodCidr"`
-	// ServiceCidr is the Service CIDR range to assign service IP addresses.
+	// ServiceCidr is the Service CIDR range to assign service IP addresses. Dual-stack clusters
+	// take an IPv4 and an IPv6 CIDR, separated by a comma.
 	ServiceCidr string `yaml:"ServiceCidr"`
+	// IPFamily is the IP family of the cluster's networking: ipv4, ipv6 or dual.
+	// Default is ipv4.
+	IPFamily string `yaml:"IpFamily"`
 	// TkrLocation is the path to the Tanzu Kubernetes Release (TKR) data.
 	TkrLocation string `yaml:"TkrLocation"`
 	// AdditionalPackageRepos are the extra package repositories to install during bootstrapping
@@ -104,12 +225,78 @@ type UnmanagedClusterConfig struct {
 	// SkipPreflightChecks determines whether preflight checks are performed prior
 	// to attempting to deploy the cluster.
 	SkipPreflightChecks bool `yaml:"SkipPreflight"`
+	// RollbackOnFailure determines whether a cluster that fails to bootstrap is deleted, along with its
+	// cluster directory and kubeconfig context. The bootstrap logs are kept as failure artifacts.
+	RollbackOnFailure bool `yaml:"RollbackOnFailure"`
 	// ControlPlaneNodeCount is the number of control plane nodes to deploy for the cluster.
 	// Default is 1
 	ControlPlaneNodeCount string `yaml:"ControlPlaneNodeCount"`
 	// WorkerNodeCount is the number of worker nodes to deploy for the cluster.
 	// Default is 0
 	WorkerNodeCount string `yaml:"WorkerNodeCount"`
+	// Nodes customizes each node of the cluster. When set, it replaces ControlPlaneNodeCount and
+	// WorkerNodeCount. Only supported by the kind provider.
+	Nodes []Node `yaml:"Nodes"`
+	// SchedulableControlPlanes determines whether the NoSchedule taints of control plane nodes are removed
+	// once the cluster is created, so workloads are scheduled to them. It is always set when there are
+	// multiple control plane nodes and no worker nodes.
+	SchedulableControlPlanes bool `yaml:"SchedulableControlPlanes"`
+	// FeatureGates enables or disables Kubernetes feature gates on all components of the cluster.
+	// Only supported by the kind provider.
+	FeatureGates map[string]bool `yaml:"FeatureGates"`
+	// RuntimeConfig enables or disables API versions of the API server (e.g. api/alpha: "true").
+	// Only supported by the kind provider.
+	RuntimeConfig map[string]string `yaml:"RuntimeConfig"`
+	// APIServerExtraArgs are flags passed to the API server, without the leading dashes
+	// (e.g. enable-admission-plugins: NodeRestriction). Only supported by the kind provider.
+	APIServerExtraArgs map[string]string `yaml:"ApiServerExtraArgs"`
+	// KubeletExtraArgs are flags passed to the kubelet of every node, without the leading dashes.
+	// Only supported by the kind provider.
+	KubeletExtraArgs map[string]string `yaml:"KubeletExtraArgs"`
+	// KubeadmConfigPatches are merge patches applied to the kubeadm configuration of every node,
+	// after the settings above. Only supported by the kind provider.
+	KubeadmConfigPatches []string `yaml:"KubeadmConfigPatches"`
+	// Timeout is the maximum duration (e.g. 30m) that bootstrapping the cluster may take.
+	// Default is no timeout
+	Timeout string `yaml:"Timeout"`
+	// KappTimeout is the maximum duration to wait for kapp-controller to be running.
+	// Default is 5m
+	KappTimeout string `yaml:"KappTimeout"`
+	// RepoTimeout is the maximum duration to wait for the core package repository to reconcile.
+	// Default is 10m
+	RepoTimeout string `yaml:"RepoTimeout"`
+	// Packages are installed, in order, after the package repositories have reconciled.
+	Packages []Package `yaml:"Packages"`
+	// PackageTimeout is the maximum duration to wait for each package install to reconcile.
+	// Default is 10m
+	PackageTimeout string `yaml:"PackageTimeout"`
+	// FromBundle is the path to a bundle archive, created by bundle export, to bootstrap the cluster
+	// from instead of pulling from registries.
+	FromBundle string `yaml:"FromBundle"`
+	// EnableLocalRegistry determines whether a registry container is started alongside the cluster
+	// and used as a mirror by the cluster's nodes. Only supported by the kind provider.
+	EnableLocalRegistry bool `yaml:"EnableLocalRegistry"`
+	// LocalRegistry is the host address (e.g. localhost:5001) of the registry container started
+	// alongside the cluster. It is set when the local registry is enabled or when bootstrapping
+	// from a bundle.
+	LocalRegistry string `yaml:"LocalRegistry"`
+	// RegistryMirrors are the mirrors the cluster's nodes pull images of a registry through.
+	RegistryMirrors []RegistryMirror `yaml:"RegistryMirrors"`
+	// InsecureRegistries are registry hosts whose TLS certificates are not verified, by both the
+	// cluster's nodes and kapp-controller.
+	InsecureRegistries []string `yaml:"InsecureRegistries"`
+	// CACertificates are paths to PEM encoded certificate authorities that the cluster's nodes and
+	// kapp-controller trust, in addition to the system's.
+	CACertificates []string `yaml:"CACertificates"`
+	// HTTPProxy is the proxy that HTTP requests are sent through, by the CLI, the cluster's nodes
+	// and kapp-controller.
+	HTTPProxy string `yaml:"HttpProxy"`
+	// HTTPSProxy is the proxy that HTTPS requests are sent through, by the CLI, the cluster's nodes
+	// and kapp-controller.
+	HTTPSProxy string `yaml:"HttpsProxy"`
+	// NoProxy is a comma separated list of hosts, domains and CIDRs that are not proxied. The
+	// loopback interface, PodCidr, ServiceCidr and the cluster domain are always excluded.
+	NoProxy string `yaml:"NoProxy"`
 }
 
 // KubeConfigPath gets the full path to the KubeConfig for this unmanaged cluster.
@@ -158,6 +345,17 @@ func GetUnmanagedConfigPath() (string, error) {
 	return filepath.Join(path, unmanagedConfigDir), nil
 }
 
+// DefaultTkrLocation returns the TKR that clusters are created from when none is configured.
+func DefaultTkrLocation() string {
+	return defaultConfigValues[TKRLocation].(string)
+}
+
+// DefaultAdditionalPackageRepos returns the package repositories that are installed when none are
+// configured.
+func DefaultAdditionalPackageRepos() []string {
+	return append([]string{}, defaultConfigValues[AdditionalPackageRepos].([]string)...)
+}
+
 // InitializeConfiguration determines the configuration to use for cluster creation.
 //
 // There are three places where configuration comes from:
@@ -195,7 +393,10 @@ func InitializeConfiguration(commandArgs map[string]interface{}) (*UnmanagedClus
 		case reflect.String:
 			setStringValue(commandArgs, &element, &field)
 		case reflect.Slice:
-			setStringSliceValue(commandArgs, &element, &field)
+			// Only string slices can be set from arguments, environment variables and defaults
+			if field.Type.Elem().Kind() == reflect.String {
+				setStringSliceValue(commandArgs, &element, &field)
+			}
 		}
 	}
 
@@ -254,7 +455,7 @@ func setStringSliceValue(commandArgs map[string]interface{}, element *reflect.Va
 			newSlice := reflect.Append(oldSlice, reflect.ValueOf(val))
 			element.FieldByName(field.Name).Set(newSlice)
 		}
-	} else if value := os.Getenv(fieldNameToEnvName(fieldName)); value != "" {
+	} else if value := os.Getenv(fieldNameToEnvName(fieldName)); value != "" && !envExcludedFields[fieldName] {
 		// Split the env var on `,` for setting multiple values
 		values := strings.Split(value, ",")
 		for _, val := range values {
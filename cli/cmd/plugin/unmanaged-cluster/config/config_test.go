@@ -79,6 +79,22 @@ func TestInitializeConfigurationDefaults(t *testing.T) {
 	if config.WorkerNodeCount != defaultConfigValues[WorkerNodeCount] {
 		t.Errorf("expected default WorkerNodeCount, was: %q", config.ControlPlaneNodeCount)
 	}
+
+	if config.Timeout != "" {
+		t.Errorf("expected no default Timeout, was: %q", config.Timeout)
+	}
+
+	if config.KappTimeout != defaultConfigValues[KappTimeout] {
+		t.Errorf("expected default KappTimeout, was: %q", config.KappTimeout)
+	}
+
+	if config.RepoTimeout != defaultConfigValues[RepoTimeout] {
+		t.Errorf("expected default RepoTimeout, was: %q", config.RepoTimeout)
+	}
+
+	if config.PackageTimeout != defaultConfigValues[PackageTimeout] {
+		t.Errorf("expected default PackageTimeout, was: %q", config.PackageTimeout)
+	}
 }
 
 func TestInitializeConfigurationEnvVariables(t *testing.T) {
@@ -130,6 +146,32 @@ func TestInitializeConfigurationEnvVariables(t *testing.T) {
 	}
 }
 
+func TestInitializeConfigurationProxyEnvVariables(t *testing.T) {
+	os.Setenv("TANZU_HTTP_PROXY", "http://proxy.example.com:3128")
+	os.Setenv("TANZU_HTTPS_PROXY", "http://proxy.example.com:3129")
+	os.Setenv("TANZU_NO_PROXY", "example.com,10.0.0.0/8")
+	defer os.Setenv("TANZU_HTTP_PROXY", "")
+	defer os.Setenv("TANZU_HTTPS_PROXY", "")
+	defer os.Setenv("TANZU_NO_PROXY", "")
+
+	config, err := InitializeConfiguration(map[string]interface{}{ClusterName: "test5"})
+	if err != nil {
+		t.Error("initialization should pass")
+	}
+
+	if config.HTTPProxy != "http://proxy.example.com:3128" {
+		t.Errorf("expected HttpProxy to be set from TANZU_HTTP_PROXY, was: %q", config.HTTPProxy)
+	}
+
+	if config.HTTPSProxy != "http://proxy.example.com:3129" {
+		t.Errorf("expected HttpsProxy to be set from TANZU_HTTPS_PROXY, was: %q", config.HTTPSProxy)
+	}
+
+	if config.NoProxy != "example.com,10.0.0.0/8" {
+		t.Errorf("expected NoProxy to be set from TANZU_NO_PROXY, was: %q", config.NoProxy)
+	}
+}
+
 func TestInitializeConfigurationArgsTakePrecedent(t *testing.T) {
 	os.Setenv("TANZU_PROVIDER", "test_provider")
 	os.Setenv("TANZU_CLUSTER_NAME", "test2")
@@ -197,6 +239,18 @@ func TestInitializeConfigurationFromConfigFile(t *testing.T) {
 		AdditionalPackageRepos: []string{"example.registry.com", "another.example.com"},
 		ControlPlaneNodeCount:  "99",
 		WorkerNodeCount:        "25",
+		Packages: []Package{
+			{
+				Name:      "cert-manager",
+				Version:   ">=1.5.0",
+				Namespace: "cert-manager",
+				Values:    map[string]interface{}{"namespace": "cert-manager"},
+			},
+		},
+		RegistryMirrors: []RegistryMirror{
+			{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}},
+		},
+		InsecureRegistries: []string{"insecure.example.com"},
 	}); err != nil {
 		t.Errorf("failed setting up test data")
 		return
@@ -258,6 +312,71 @@ func TestInitializeConfigurationFromConfigFile(t *testing.T) {
 	if config.WorkerNodeCount != "25" {
 		t.Errorf("expected WorkerNodeCount to be set to '25', was: %q", config.WorkerNodeCount)
 	}
+
+	if len(config.Packages) != 1 {
+		t.Fatalf("expected 1 package, was: %d", len(config.Packages))
+	}
+
+	if config.Packages[0].Name != "cert-manager" || config.Packages[0].Version != ">=1.5.0" {
+		t.Errorf("expected package 'cert-manager' version '>=1.5.0', was: %q version %q", config.Packages[0].Name, config.Packages[0].Version)
+	}
+
+	if config.Packages[0].Values["namespace"] != "cert-manager" {
+		t.Errorf("expected package values to be read, was: %v", config.Packages[0].Values)
+	}
+
+	if len(config.RegistryMirrors) != 1 || config.RegistryMirrors[0].Registry != "docker.io" ||
+		len(config.RegistryMirrors[0].Endpoints) != 1 || config.RegistryMirrors[0].Endpoints[0] != "https://mirror.example.com" {
+		t.Errorf("expected a mirror of 'docker.io' at 'https://mirror.example.com', was: %v", config.RegistryMirrors)
+	}
+
+	if len(config.InsecureRegistries) != 1 || config.InsecureRegistries[0] != "insecure.example.com" {
+		t.Errorf("expected InsecureRegistries to be 'insecure.example.com', was: %v", config.InsecureRegistries)
+	}
+}
+
+func TestInitializeConfigurationIgnoresStructSliceEnvVariables(t *testing.T) {
+	os.Setenv("TANZU_PACKAGES", "cert-manager")
+	os.Setenv("TANZU_PORTS_TO_FORWARD", "80")
+	defer os.Setenv("TANZU_PACKAGES", "")
+	defer os.Setenv("TANZU_PORTS_TO_FORWARD", "")
+
+	config, err := InitializeConfiguration(map[string]interface{}{ClusterName: "test4"})
+	if err != nil {
+		t.Error("initialization should pass")
+	}
+
+	if len(config.Packages) != 0 {
+		t.Errorf("expected no packages, was: %v", config.Packages)
+	}
+
+	if len(config.PortsToForward) != 0 {
+		t.Errorf("expected no ports to forward, was: %v", config.PortsToForward)
+	}
+}
+
+func TestInitializeConfigurationIgnoresKubeadmConfigPatchesEnvVariable(t *testing.T) {
+	os.Setenv("TANZU_KUBEADM_CONFIG_PATCHES", "kind: ClusterConfiguration\napiServer:\n  certSANs: [a.example.com, b.example.com]")
+	defer os.Setenv("TANZU_KUBEADM_CONFIG_PATCHES", "")
+
+	config, err := InitializeConfiguration(map[string]interface{}{ClusterName: "test5"})
+	if err != nil {
+		t.Error("initialization should pass")
+	}
+
+	if len(config.KubeadmConfigPatches) != 0 {
+		t.Errorf("expected no kubeadm config patches, was: %v", config.KubeadmConfigPatches)
+	}
+
+	patch := "kind: KubeletConfiguration\nmaxPods: 200"
+	config, err = InitializeConfiguration(map[string]interface{}{ClusterName: "test5", KubeadmConfigPatches: []string{patch}})
+	if err != nil {
+		t.Error("initialization should pass")
+	}
+
+	if len(config.KubeadmConfigPatches) != 1 || config.KubeadmConfigPatches[0] != patch {
+		t.Errorf("expected the kubeadm config patch argument, was: %v", config.KubeadmConfigPatches)
+	}
 }
 
 func TestFieldNameToEnvName(t *testing.T) {
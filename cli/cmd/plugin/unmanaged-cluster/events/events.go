@@ -0,0 +1,100 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events provides machine-readable progress reporting for bootstrapping unmanaged clusters.
+// Events are written as JSON objects, one per line, so tools such as IDE integrations and CI
+// dashboards can follow cluster creation without parsing the human-readable log output.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Status describes the state of a bootstrapping phase when an event is emitted.
+type Status string
+
+const (
+	// StatusStarted is emitted when a phase begins.
+	StatusStarted Status = "Started"
+	// StatusSucceeded is emitted when a phase completes successfully.
+	StatusSucceeded Status = "Succeeded"
+	// StatusSkipped is emitted when a phase does not need to run, such as when resuming.
+	StatusSkipped Status = "Skipped"
+	// StatusFailed is emitted when a phase fails.
+	StatusFailed Status = "Failed"
+)
+
+// Event is a single progress update for a bootstrapping phase.
+type Event struct {
+	// Cluster is the name of the cluster being bootstrapped.
+	Cluster string `json:"cluster"`
+	// Phase is the name of the bootstrapping phase.
+	Phase string `json:"phase"`
+	// Status is the state of the phase.
+	Status Status `json:"status"`
+	// Timestamp is when the event was emitted.
+	Timestamp time.Time `json:"timestamp"`
+	// StartTime is when the phase started. It is only set on events that finish a phase.
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// DurationSeconds is how long the phase ran for. It is only set on events that finish a phase.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	// Details holds phase specific information, such as resolved images and status strings.
+	Details map[string]string `json:"details,omitempty"`
+	// ExitCode is the exit code of a failed phase.
+	ExitCode int `json:"exitCode,omitempty"`
+	// Error is the error message of a failed phase.
+	Error string `json:"error,omitempty"`
+}
+
+// Emitter publishes bootstrapping events.
+type Emitter interface {
+	// Emit publishes an event. The timestamp of the event is set when it is emitted. Events that
+	// finish a phase are given the start time and duration of the phase, if it was started.
+	// Emitting is best effort, events that cannot be published are dropped.
+	Emit(e Event)
+}
+
+// jsonEmitter writes events as newline delimited JSON.
+type jsonEmitter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	started map[string]time.Time
+}
+
+// NewJSONEmitter returns an Emitter that writes each event as a single line of JSON to w.
+func NewJSONEmitter(w io.Writer) Emitter {
+	return &jsonEmitter{
+		encoder: json.NewEncoder(w),
+		started: map[string]time.Time{},
+	}
+}
+
+func (j *jsonEmitter) Emit(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	e.Timestamp = time.Now().UTC()
+	if e.Status == StatusStarted {
+		j.started[e.Phase] = e.Timestamp
+	} else if start, ok := j.started[e.Phase]; ok {
+		e.StartTime = &start
+		e.DurationSeconds = e.Timestamp.Sub(start).Seconds()
+		delete(j.started, e.Phase)
+	}
+
+	_ = j.encoder.Encode(e)
+}
+
+// noopEmitter discards all events.
+type noopEmitter struct{}
+
+// NewNoopEmitter returns an Emitter that discards all events. It is used when no machine-readable
+// output was requested.
+func NewNoopEmitter() Emitter {
+	return noopEmitter{}
+}
+
+func (noopEmitter) Emit(e Event) {}
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -68,7 +69,7 @@ type Manager interface {
 	// Status retrieves the pod status for kapp-controller. It expects to be passed the namespace and name for the
 	// kapp-controller Deployment object. If it cannot talk to the cluster, that status is reported. If the
 	// pod cannot be resolved, a status of not created is reported. Otherwise, the exact status message is returned.
-	Status(ns, name string) string
+	Status(ctx context.Context, ns, name string) string
 }
 
 // New instantiates a new KappManager.
@@ -174,8 +175,8 @@ func (k Client) Install(opts InstallOpts) (*v1.Deployment, error) {
 }
 
 // Status gets the status of a package.
-func (k Client) Status(ns, name string) string {
-	pods, err := k.clientSet.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{})
+func (k Client) Status(ctx context.Context, ns, name string) string {
+	pods, err := k.clientSet.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "failed to talk to cluster"
 	}
@@ -200,7 +201,8 @@ func (k Client) Status(ns, name string) string {
 // applyObject takes a runtime.Object and converts it into an unstructured object. It then
 // uses the dynamic client to apply the object to the cluster. If the namespace field is nil,
 // it applies the object cluster wide, if it contains a string, it applies it in the
-// appropriate namespace.
+// appropriate namespace. If the object already exists, for example from a previous install
+// that was interrupted, it is updated in place.
 func applyObject(k Client, obj runtime.Object) (*unstructured.Unstructured, error) {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	gk := schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}
@@ -212,34 +214,29 @@ func applyObject(k Client, obj runtime.Object) (*unstructured.Unstructured, erro
 	objectBody := &unstructured.Unstructured{Object: uObj}
 
 	mapping, _ := k.restMapper.RESTMapping(gk, gvk.Version)
+	gvr := schema.GroupVersionResource{
+		Group:    gvk.Group,
+		Version:  gvk.Version,
+		Resource: mapping.Resource.Resource,
+	}
 
+	var resourceClient dynamic.ResourceInterface = k.dynClient.Resource(gvr)
 	nsInterface := uObj[metadataKey].(map[string]interface{})[namespaceKey]
-	var createObj *unstructured.Unstructured
 	if nsInterface != nil {
-		ns := nsInterface.(string)
-		createObj, err = k.dynClient.
-			Resource(schema.GroupVersionResource{
-				Group:    gvk.Group,
-				Version:  gvk.Version,
-				Resource: mapping.Resource.Resource,
-			}).Namespace(ns).
-			Create(context.TODO(), objectBody, metav1.CreateOptions{})
-
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		createObj, err = k.dynClient.
-			Resource(schema.GroupVersionResource{
-				Group:    gvk.Group,
-				Version:  gvk.Version,
-				Resource: mapping.Resource.Resource,
-			}).
-			Create(context.TODO(), objectBody, metav1.CreateOptions{})
+		resourceClient = k.dynClient.Resource(gvr).Namespace(nsInterface.(string))
+	}
 
+	createObj, err := resourceClient.Create(context.TODO(), objectBody, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		existingObj, err := resourceClient.Get(context.TODO(), objectBody.GetName(), metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
+		objectBody.SetResourceVersion(existingObj.GetResourceVersion())
+		return resourceClient.Update(context.TODO(), objectBody, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return createObj, nil
@@ -3,6 +3,12 @@
 
 package kapp
 
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 // This is entirely a workaround until we've got better plumbing
 
 const (
@@ -29,3 +35,47 @@ kappController:
     dangerousSkipTLSVerify: ""
 `
 )
+
+// ConfigValues are kapp-controller config values that depend on the cluster configuration. They
+// are rendered as an additional values file, which overrides DefaultKappValues.
+type ConfigValues struct {
+	// CACerts are PEM encoded certificate authorities kapp-controller trusts when connecting to registries.
+	CACerts string
+	// DangerousSkipTLSVerify are the registry hosts kapp-controller connects to without verifying TLS.
+	DangerousSkipTLSVerify []string
+	// HTTPProxy is the proxy kapp-controller sends HTTP requests through.
+	HTTPProxy string
+	// HTTPSProxy is the proxy kapp-controller sends HTTPS requests through.
+	HTTPSProxy string
+	// NoProxy is a comma separated list of hosts kapp-controller connects to directly.
+	NoProxy string
+}
+
+// Render renders the values that are set. Nil is returned when no values are set.
+func (v *ConfigValues) Render() ([]byte, error) {
+	cfg := map[string]interface{}{}
+	if v.CACerts != "" {
+		cfg["caCerts"] = v.CACerts
+	}
+	if len(v.DangerousSkipTLSVerify) != 0 {
+		cfg["dangerousSkipTLSVerify"] = strings.Join(v.DangerousSkipTLSVerify, ",")
+	}
+	if v.HTTPProxy != "" {
+		cfg["httpProxy"] = v.HTTPProxy
+	}
+	if v.HTTPSProxy != "" {
+		cfg["httpsProxy"] = v.HTTPSProxy
+	}
+	if v.NoProxy != "" {
+		cfg["noProxy"] = v.NoProxy
+	}
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+
+	return yaml.Marshal(map[string]interface{}{
+		"kappController": map[string]interface{}{
+			"config": cfg,
+		},
+	})
+}
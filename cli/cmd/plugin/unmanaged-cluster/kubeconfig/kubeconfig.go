@@ -7,6 +7,7 @@
 package kubeconfig
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -18,6 +19,9 @@ import (
 	clientcmdapilatest "k8s.io/client-go/tools/clientcmd/api/latest"
 )
 
+// backupSuffix is appended to the path of the default kubeconfig to name its backup.
+const backupSuffix = ".unmanaged-cluster.bak"
+
 // GetKubeconfigContext returns the current context for a passed in kubeconfig file
 // Returns an empty string if no context is set
 func GetKubeconfigContext(filepath string) (string, error) {
@@ -35,6 +39,36 @@ func GetKubeconfigContext(filepath string) (string, error) {
 	return startingConfig.CurrentContext, nil
 }
 
+// RenameContext renames a context of the passed in kubeconfig file. The current context is updated
+// when it is the renamed context. An error is returned if the context does not exist, or if a context
+// with the new name already does.
+func RenameContext(filepath, name, newName string) error {
+	config, err := clientcmd.LoadFromFile(filepath)
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := config.Contexts[name]
+	if !ok {
+		return fmt.Errorf("context %s not found in %s", name, filepath)
+	}
+	if _, exists := config.Contexts[newName]; exists {
+		return fmt.Errorf("context %s already exists in %s", newName, filepath)
+	}
+	delete(config.Contexts, name)
+	config.Contexts[newName] = kubeContext
+	if config.CurrentContext == name {
+		config.CurrentContext = newName
+	}
+
+	output, err := encodeConfig(config)
+	if err != nil {
+		return err
+	}
+
+	return writeKubeConfigFile(filepath, output, 0600)
+}
+
 // KubeConfig contains information about the kubeconfig location.
 type KubeConfig struct {
 	defaultConfigLocation string
@@ -48,7 +82,17 @@ type Manager interface {
 	MergeToDefaultConfig(kubeconfigPath string) error
 	// SetCurrentContext changes the kubeconfig context (`current-context` value) to the name passed in.
 	SetCurrentContext(name string) error
-	// TODO(joshrosso): we should considering introducing a backup capability that is called as part of this process.
+	// RemoveContext removes the named context from the default kubeconfig, along with the cluster and user
+	// it references when no other context uses them. If the removed context was the current context, the
+	// current context is unset. When the context does not exist, the kubeconfig is left untouched.
+	RemoveContext(name string) error
+	// Backup copies the default kubeconfig next to it, replacing the previous backup, and returns the path
+	// of the backup. It should be called before the default kubeconfig is modified. An empty path is returned
+	// when there is no default kubeconfig to back up.
+	Backup() (string, error)
+	// Export returns a standalone kubeconfig holding only the named context from the default kubeconfig, with
+	// the cluster and user it references. Certificates and keys referenced by path are embedded.
+	Export(name string) ([]byte, error)
 }
 
 // NewManager returns a KubeConfigMgr implemented by KubeConfig.
@@ -105,6 +149,94 @@ func (kc *KubeConfig) SetCurrentContext(name string) error {
 	return nil
 }
 
+// RemoveContext removes a context, and its unshared cluster and user, from the kubeconfig file.
+func (kc *KubeConfig) RemoveContext(name string) error {
+	rules := clientcmd.ClientConfigLoadingRules{
+		Precedence: []string{kc.defaultConfigLocation},
+	}
+	loadedRules, err := rules.Load()
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := loadedRules.Contexts[name]
+	if !ok {
+		return nil
+	}
+	delete(loadedRules.Contexts, name)
+
+	clusterInUse, userInUse := false, false
+	for _, c := range loadedRules.Contexts {
+		if c.Cluster == kubeContext.Cluster {
+			clusterInUse = true
+		}
+		if c.AuthInfo == kubeContext.AuthInfo {
+			userInUse = true
+		}
+	}
+	if !clusterInUse {
+		delete(loadedRules.Clusters, kubeContext.Cluster)
+	}
+	if !userInUse {
+		delete(loadedRules.AuthInfos, kubeContext.AuthInfo)
+	}
+
+	if loadedRules.CurrentContext == name {
+		loadedRules.CurrentContext = ""
+	}
+
+	output, err := encodeConfig(loadedRules)
+	if err != nil {
+		return err
+	}
+
+	if err := writeKubeConfigFile(kc.defaultConfigLocation, output, 0600); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Backup copies the kubeconfig file to its backup file.
+func (kc *KubeConfig) Backup() (string, error) {
+	data, err := os.ReadFile(kc.defaultConfigLocation)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	backupPath := kc.defaultConfigLocation + backupSuffix
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// Export extracts a context, and its cluster and user, from the kubeconfig file.
+func (kc *KubeConfig) Export(name string) ([]byte, error) {
+	rules := clientcmd.ClientConfigLoadingRules{
+		Precedence: []string{kc.defaultConfigLocation},
+	}
+	loadedRules, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := loadedRules.Contexts[name]; !ok {
+		return nil, fmt.Errorf("context %s not found in %s", name, kc.defaultConfigLocation)
+	}
+	loadedRules.CurrentContext = name
+	if err := clientcmdapi.MinifyConfig(loadedRules); err != nil {
+		return nil, err
+	}
+	if err := clientcmdapi.FlattenConfig(loadedRules); err != nil {
+		return nil, err
+	}
+
+	return encodeConfig(loadedRules)
+}
+
 // encodeConfig takes the [kube]config struct from the Kubernetes API and returns the YAML
 // representation in byte format.
 func encodeConfig(config *clientcmdapi.Config) ([]byte, error) {
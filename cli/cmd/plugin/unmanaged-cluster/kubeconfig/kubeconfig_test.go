@@ -0,0 +1,116 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const twoClusterKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-one
+  cluster:
+    server: https://127.0.0.1:6443
+- name: kind-two
+  cluster:
+    server: https://127.0.0.1:7443
+contexts:
+- name: kind-one
+  context:
+    cluster: kind-one
+    user: kind-one
+- name: kind-two
+  context:
+    cluster: kind-two
+    user: kind-two
+current-context: kind-one
+users:
+- name: kind-one
+  user:
+    token: one
+- name: kind-two
+  user:
+    token: two
+`
+
+func newTestManager(t *testing.T) *KubeConfig {
+	kc := &KubeConfig{defaultConfigLocation: filepath.Join(t.TempDir(), "config")}
+	err := os.WriteFile(kc.defaultConfigLocation, []byte(twoClusterKubeconfig), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kc
+}
+
+func TestBackup(t *testing.T) {
+	kc := newTestManager(t)
+
+	backupPath, err := kc.Backup()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := os.ReadFile(backupPath)
+	if err != nil || string(data) != twoClusterKubeconfig {
+		t.Errorf("expected the backup to match the kubeconfig, error: %v", err)
+	}
+
+	kc.defaultConfigLocation = filepath.Join(t.TempDir(), "missing")
+	backupPath, err = kc.Backup()
+	if err != nil || backupPath != "" {
+		t.Errorf("expected no backup of a missing kubeconfig, got %q, error: %v", backupPath, err)
+	}
+}
+
+func TestRemoveContext(t *testing.T) {
+	kc := newTestManager(t)
+
+	err := kc.RemoveContext("kind-one")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	config, err := clientcmd.LoadFromFile(kc.defaultConfigLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Contexts["kind-one"]; ok {
+		t.Error("expected the kind-one context to be removed")
+	}
+	if _, ok := config.Clusters["kind-one"]; ok {
+		t.Error("expected the orphaned kind-one cluster to be removed")
+	}
+	if _, ok := config.AuthInfos["kind-one"]; ok {
+		t.Error("expected the orphaned kind-one user to be removed")
+	}
+	if _, ok := config.Contexts["kind-two"]; !ok || config.CurrentContext != "" {
+		t.Errorf("expected kind-two to be kept and the current context unset, got %v", config)
+	}
+}
+
+func TestExport(t *testing.T) {
+	kc := newTestManager(t)
+
+	data, err := kc.Export("kind-two")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		t.Fatalf("exported kubeconfig is not valid: %s", err)
+	}
+	if config.CurrentContext != "kind-two" || len(config.Contexts) != 1 || len(config.Clusters) != 1 || len(config.AuthInfos) != 1 {
+		t.Errorf("expected only the kind-two context, cluster and user, got %v", config)
+	}
+	if config.AuthInfos["kind-two"].Token != "two" {
+		t.Errorf("expected the kind-two user to be exported, got %v", config.AuthInfos)
+	}
+
+	if _, err := kc.Export("kind-three"); err == nil {
+		t.Error("expected an error for an unknown context")
+	}
+}
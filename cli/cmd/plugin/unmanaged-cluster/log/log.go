@@ -50,6 +50,8 @@ type CMDLogger struct {
 	logColor color.Attribute
 	// output controls where log messages are sent
 	output io.Writer
+	// console is where log messages are displayed, regardless of any added log file
+	console io.Writer
 }
 
 // Logger provides the logging interaction for the application.
@@ -109,13 +111,23 @@ type Logger interface {
 
 // NewLogger returns an instance of Logger, implemented via CMDLogger.
 func NewLogger(tty bool, level int) Logger {
+	return NewLoggerWithOutput(tty, level, os.Stdout)
+}
+
+// NewLoggerWithOutput returns an instance of Logger that displays log messages on the provided
+// console writer rather than stdout. This allows stdout to be reserved for machine-readable output.
+func NewLoggerWithOutput(tty bool, level int, console io.Writer) Logger {
 	fd := int(os.Stdout.Fd())
+	if f, ok := console.(*os.File); ok {
+		fd = int(f.Fd())
+	}
 
 	return &CMDLogger{
-		tty:    tty,
-		level:  level,
-		output: os.Stdout,
-		termFd: fd,
+		tty:     tty,
+		level:   level,
+		output:  console,
+		console: console,
+		termFd:  fd,
 	}
 }
 
@@ -126,7 +138,7 @@ func (l *CMDLogger) AddLogFile(filePath string) {
 		return
 	}
 
-	l.output = io.MultiWriter(logFile, os.Stdout)
+	l.output = io.MultiWriter(logFile, l.console)
 }
 
 func (l *CMDLogger) Event(emoji, message string) {
@@ -143,7 +155,7 @@ func (l *CMDLogger) Event(emoji, message string) {
 
 	// Print a new line before the event is logged
 	// so that each event is within it's own "block"
-	fmt.Print("\n")
+	fmt.Fprint(l.console, "\n")
 
 	// process indentation and ensure a space after the emoji and a new line after message
 	message = "%s " + message + "\n"
@@ -165,7 +177,7 @@ func (l *CMDLogger) Eventf(emoji, message string, args ...interface{}) {
 
 	// Print a new line before the event is logged
 	// so that each event is within it's own "block"
-	fmt.Print("\n")
+	fmt.Fprint(l.console, "\n")
 
 	// ensure a space between the emoji and the message
 	message = emoji + " " + message
@@ -179,7 +191,7 @@ func (l *CMDLogger) Warn(message string) {
 	}
 
 	message = processStyle(l, message)
-	fmt.Println(message)
+	fmt.Fprintln(l.console, message)
 }
 
 func (l *CMDLogger) Warnf(message string, args ...interface{}) {
@@ -197,7 +209,7 @@ func (l *CMDLogger) Error(message string) {
 	}
 
 	message = processStyle(l, message)
-	fmt.Println(message)
+	fmt.Fprintln(l.console, message)
 }
 
 func (l *CMDLogger) Errorf(message string, args ...interface{}) {
@@ -215,7 +227,7 @@ func (l *CMDLogger) Info(message string) {
 	}
 
 	message = processStyle(l, message)
-	fmt.Println(message)
+	fmt.Fprintln(l.console, message)
 }
 
 func (l *CMDLogger) Infof(message string, args ...interface{}) {
@@ -288,7 +300,7 @@ func (l *CMDLogger) progressf(count int, message string, args ...interface{}) {
 		buffer = sb.String()
 	}
 
-	fmt.Print(buffer)
+	fmt.Fprint(l.console, buffer)
 }
 
 func (l *CMDLogger) ReplaceLinef(message string, args ...interface{}) {
@@ -369,6 +381,7 @@ func (l *CMDLogger) V(level int) Logger {
 		level:    l.level,
 		logLevel: level,
 		output:   l.output,
+		console:  l.console,
 	}
 }
 
@@ -384,6 +397,7 @@ func (l *CMDLogger) Style(indent int, c color.Attribute) Logger {
 		indent:   indent,
 		logColor: c,
 		output:   l.output,
+		console:  l.console,
 	}
 }
 
@@ -39,10 +39,16 @@ func main() {
 	p.Cmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Log file path")
 
 	p.AddCommands(
+		cmd.BundleCmd,
 		cmd.ConfigureCmd,
 		cmd.CreateCmd,
 		cmd.DeleteCmd,
+		cmd.DescribeCmd,
+		cmd.KubeconfigCmd,
 		cmd.ListCmd,
+		cmd.StartCmd,
+		cmd.StopCmd,
+		cmd.UpgradeCmd,
 	)
 	if err := p.Execute(); err != nil {
 		os.Exit(1)
@@ -18,6 +18,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/kubernetes"
@@ -39,6 +40,8 @@ const (
 	tkgSysNamespace        = "tkg-system"
 	rbacAPIGroup           = "rbac.authorization.k8s.io"
 	apiBaseURI             = "/apis"
+	// yttOverlayAnnotation references a secret holding ytt overlays to apply to a package's templates
+	yttOverlayAnnotation = "ext.packaging.carvel.dev/ytt-paths-from-secret-name.0"
 )
 
 // PackageClient implements PackageManager and holds references to both
@@ -69,6 +72,9 @@ type PackageInstallOpts struct {
 	// Optional configuration to be added alongside the package installation. When this value is non-nil, a
 	// Secret object is created in the cluster and the package install references it as a values configuration.
 	Configuration []byte
+	// Optional ytt overlays applied to the templates of the package. When this value is non-nil, a Secret object
+	// is created in the cluster and the package install references it through an annotation.
+	Overlays []byte
 	// The ServiceAccount used to facilitate the package install. It must have all privileges required for
 	// kapp-controller to create the appropriate objects.
 	ServiceAccount string
@@ -87,13 +93,26 @@ type PackageManager interface {
 	// by injecting a secret object into the cluster and referencing it from the package install.
 	// Upon success, it returns the created PackageInstall object.
 	CreatePackageInstall(opts *PackageInstallOpts) (*packaging.PackageInstall, error)
+	// UpdatePackageRepo points an existing PackageRepository at a new imgpkg bundle. It does not
+	// wait for the package repository to reconcile. Upon success, it returns the updated
+	// PackageRepository object.
+	UpdatePackageRepo(ns, name, url string) (*packaging.PackageRepository, error)
+	// UpdatePackageInstallVersion changes the version constraint of an existing PackageInstall,
+	// keeping its configuration. It does not wait for the package install to reconcile. Upon
+	// success, it returns the updated PackageInstall object.
+	UpdatePackageInstallVersion(ns, name, version string) (*packaging.PackageInstall, error)
 	// CreateRootServiceAccount creates a service account in the target namespace with a ClusterRoleBinding
 	// referencing the cluster-admin CluterRole. This essentially provides full admin access to anything
-	// referencing this service account. Upon success, it returns the created ServiceAccount.
+	// referencing this service account. Upon success, it returns the created ServiceAccount. If the
+	// service account already exists, the existing ServiceAccount is returned. The namespace is created
+	// if it does not exist.
 	CreateRootServiceAccount(ns, name string) (*v1.ServiceAccount, error)
 	// GetRepositoryStatus outputs the status of a repository based on the namespace and repository name
 	// requested. It provides details on kapp-controller process such as "Reconciling" and "Reconcile Succeeded"
-	GetRepositoryStatus(ns, name string) (string, error)
+	GetRepositoryStatus(ctx context.Context, ns, name string) (string, error)
+	// GetPackageInstallStatus outputs the status of a package install based on the namespace and install name
+	// requested. It provides details on kapp-controller process such as "Reconciling" and "Reconcile Succeeded"
+	GetPackageInstallStatus(ctx context.Context, ns, name string) (string, error)
 	// ListPackagesInNamespace returns a list of packages based on the namespace.
 	ListPackagesInNamespace(ns string) ([]datapackaging.Package, error)
 }
@@ -235,10 +254,9 @@ func (am *PackageClient) CreatePackageInstall(opts *PackageInstallOpts) (*packag
 			StringData: values,
 		}
 
-		createdSecret, err := am.clientSet.CoreV1().Secrets(tkgSysNamespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+		createdSecret, err := am.applySecret(secret)
 		if err != nil {
-			fmt.Printf("Failed to create secret: %s", err.Error())
-			return nil, err
+			return nil, fmt.Errorf("failed to create secret %s. Error: %s", secret.Name, err.Error())
 		}
 
 		// set PackageInstall reference to created secret
@@ -247,6 +265,29 @@ func (am *PackageClient) CreatePackageInstall(opts *PackageInstallOpts) (*packag
 		}
 	}
 
+	if opts.Overlays != nil {
+		// create secret based on overlay data
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      opts.InstallName + "-overlays",
+				Namespace: opts.Namespace,
+			},
+			StringData: map[string]string{
+				"overlays.yml": string(opts.Overlays),
+			},
+		}
+
+		createdSecret, err := am.applySecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secret %s. Error: %s", secret.Name, err.Error())
+		}
+
+		// set PackageInstall reference to created secret
+		pkgInstall.ObjectMeta.Annotations = map[string]string{
+			yttOverlayAnnotation: createdSecret.Name,
+		}
+	}
+
 	// create package install object in cluster
 	createdInstall := &packaging.PackageInstall{}
 	err := am.restClient.
@@ -264,7 +305,7 @@ func (am *PackageClient) CreatePackageInstall(opts *PackageInstallOpts) (*packag
 	return createdInstall, nil
 }
 
-func (am *PackageClient) GetRepositoryStatus(ns, name string) (string, error) {
+func (am *PackageClient) UpdatePackageRepo(ns, name, url string) (*packaging.PackageRepository, error) {
 	repo := &packaging.PackageRepository{}
 	err := am.restClient.
 		Get().
@@ -273,6 +314,87 @@ func (am *PackageClient) GetRepositoryStatus(ns, name string) (string, error) {
 		Resource(packageRepoResource).
 		Do(context.TODO()).
 		Into(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.Spec.Fetch = &packaging.PackageRepositoryFetch{
+		ImgpkgBundle: &kappapis.AppFetchImgpkgBundle{
+			Image: url,
+		},
+	}
+
+	updatedRepo := &packaging.PackageRepository{}
+	err = am.restClient.
+		Put().
+		Resource(packageRepoResource).
+		Namespace(ns).
+		Name(name).
+		Body(repo).
+		Do(context.TODO()).
+		Into(updatedRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedRepo, nil
+}
+
+func (am *PackageClient) UpdatePackageInstallVersion(ns, name, version string) (*packaging.PackageInstall, error) {
+	pkgInstall := &packaging.PackageInstall{}
+	err := am.restClient.
+		Get().
+		Namespace(ns).
+		Name(name).
+		Resource(packageInstallResource).
+		Do(context.TODO()).
+		Into(pkgInstall)
+	if err != nil {
+		return nil, err
+	}
+	if pkgInstall.Spec.PackageRef == nil {
+		return nil, fmt.Errorf("package install %s/%s does not reference a package", ns, name)
+	}
+
+	pkgInstall.Spec.PackageRef.VersionSelection = &versions.VersionSelectionSemver{
+		Constraints: version,
+	}
+
+	updatedInstall := &packaging.PackageInstall{}
+	err = am.restClient.
+		Put().
+		Resource(packageInstallResource).
+		Namespace(ns).
+		Name(name).
+		Body(pkgInstall).
+		Do(context.TODO()).
+		Into(updatedInstall)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedInstall, nil
+}
+
+// applySecret creates the secret. A secret left behind by a previous attempt at an install is
+// replaced.
+func (am *PackageClient) applySecret(secret *v1.Secret) (*v1.Secret, error) {
+	createdSecret, err := am.clientSet.CoreV1().Secrets(secret.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		createdSecret, err = am.clientSet.CoreV1().Secrets(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+	}
+	return createdSecret, err
+}
+
+func (am *PackageClient) GetRepositoryStatus(ctx context.Context, ns, name string) (string, error) {
+	repo := &packaging.PackageRepository{}
+	err := am.restClient.
+		Get().
+		Namespace(ns).
+		Name(name).
+		Resource(packageRepoResource).
+		Do(ctx).
+		Into(repo)
 	if err != nil {
 		return "", err
 	}
@@ -280,6 +402,22 @@ func (am *PackageClient) GetRepositoryStatus(ns, name string) (string, error) {
 	return repo.Status.FriendlyDescription, nil
 }
 
+func (am *PackageClient) GetPackageInstallStatus(ctx context.Context, ns, name string) (string, error) {
+	pkgInstall := &packaging.PackageInstall{}
+	err := am.restClient.
+		Get().
+		Namespace(ns).
+		Name(name).
+		Resource(packageInstallResource).
+		Do(ctx).
+		Into(pkgInstall)
+	if err != nil {
+		return "", err
+	}
+
+	return pkgInstall.Status.FriendlyDescription, nil
+}
+
 func (am *PackageClient) CreateRootServiceAccount(ns, name string) (*v1.ServiceAccount, error) {
 	svcAcct := &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
@@ -288,9 +426,14 @@ func (am *PackageClient) CreateRootServiceAccount(ns, name string) (*v1.ServiceA
 		},
 	}
 
+	// Service accounts in namespaces other than tkg-system get their own binding
+	roleBindingName := name
+	if ns != tkgSysNamespace {
+		roleBindingName = fmt.Sprintf("%s-%s", name, ns)
+	}
 	roleBinding := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name: roleBindingName,
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -306,13 +449,27 @@ func (am *PackageClient) CreateRootServiceAccount(ns, name string) (*v1.ServiceA
 		},
 	}
 
-	createdSa, err := am.clientSet.CoreV1().ServiceAccounts(tkgSysNamespace).Create(context.TODO(), svcAcct, metav1.CreateOptions{})
+	namespace := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ns,
+		},
+	}
+	_, err := am.clientSet.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	// The service account and binding are shared by installs, so existing objects are reused
+	createdSa, err := am.clientSet.CoreV1().ServiceAccounts(ns).Create(context.TODO(), svcAcct, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		createdSa, err = am.clientSet.CoreV1().ServiceAccounts(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	_, err = am.clientSet.RbacV1().ClusterRoleBindings().Create(context.TODO(), roleBinding, metav1.CreateOptions{})
-	if err != nil {
+	if err != nil && !apierrors.IsAlreadyExists(err) {
 		return nil, err
 	}
 
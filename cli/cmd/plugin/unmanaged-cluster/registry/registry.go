@@ -0,0 +1,109 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registry runs a local OCI registry container next to an unmanaged cluster. The
+// registry is published on the host's loopback interface, so images can be pushed to it,
+// and attached to the network of the cluster's nodes, so they can pull from it.
+package registry
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+const (
+	// DefaultImage is the container image the registry is run from.
+	DefaultImage = "docker.io/library/registry:2"
+	// containerPort is the port the registry listens on within its container.
+	containerPort = 5000
+)
+
+// Runtime is the container runtime the registry container is run with. It must be the runtime
+// running the cluster's nodes, so the registry can join their network.
+type Runtime interface {
+	// Command returns a command that runs the runtime's CLI.
+	Command(args ...string) exec.Cmd
+}
+
+// ContainerName returns the name of the registry container of a cluster.
+func ContainerName(clusterName string) string {
+	return clusterName + "-registry"
+}
+
+// ClusterAddress returns the address the registry of a cluster is reachable at from the
+// cluster's nodes and pods.
+func ClusterAddress(clusterName string) string {
+	return fmt.Sprintf("%s:%d", ContainerName(clusterName), containerPort)
+}
+
+// Start runs the registry container of a cluster, publishing it on a free port of the host's
+// loopback interface. A registry container left behind by a previous attempt is replaced. It
+// returns the address the registry is reachable at from the host, such as localhost:5001.
+func Start(runtime Runtime, clusterName, image string) (string, error) {
+	err := Delete(runtime, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return "", fmt.Errorf("unable to find a free port for the registry. Error: %s", err.Error())
+	}
+
+	cmd := runtime.Command("run", "--detach", "--restart=always",
+		"--name", ContainerName(clusterName),
+		"--publish", fmt.Sprintf("127.0.0.1:%d:%d", port, containerPort),
+		image)
+	_, err = exec.Output(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to start registry container %s. Error: %s", ContainerName(clusterName), err.Error())
+	}
+
+	return fmt.Sprintf("localhost:%d", port), nil
+}
+
+// Connect attaches the registry container of a cluster to the network of the cluster's nodes.
+// The network only exists once the cluster was created.
+func Connect(runtime Runtime, clusterName, network string) error {
+	cmd := runtime.Command("network", "connect", network, ContainerName(clusterName))
+	out, err := exec.CombinedOutputLines(cmd)
+	if err != nil && !strings.Contains(strings.Join(out, "\n"), "already exists") {
+		return fmt.Errorf("failed to connect registry container %s to the %s network. Error: %s", ContainerName(clusterName), network, err.Error())
+	}
+	return nil
+}
+
+// Delete removes the registry container of a cluster, along with the images pushed to it. It is not
+// an error for the registry container to not exist.
+func Delete(runtime Runtime, clusterName string) error {
+	cmd := runtime.Command("rm", "--force", "--volumes", ContainerName(clusterName))
+	out, err := exec.CombinedOutputLines(cmd)
+	if err != nil && !strings.Contains(strings.ToLower(strings.Join(out, "\n")), "no such container") {
+		return fmt.Errorf("failed to remove registry container %s. Error: %s", ContainerName(clusterName), err.Error())
+	}
+	return nil
+}
+
+// ContainerdConfigPatch returns a containerd configuration patch that makes the cluster's nodes
+// pull images referenced through either address of the registry from the registry container.
+func ContainerdConfigPatch(hostAddress, clusterAddress string) string {
+	var patch strings.Builder
+	for _, address := range []string{hostAddress, clusterAddress} {
+		fmt.Fprintf(&patch, "[plugins.\"io.containerd.grpc.v1.cri\".registry.mirrors.%q]\n", address)
+		fmt.Fprintf(&patch, "  endpoint = [\"http://%s\"]\n", clusterAddress)
+	}
+	return patch.String()
+}
+
+// freePort returns a port of the loopback interface that is not in use.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/bundle"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/cluster"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/registry"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
+)
+
+// bundleDir is the directory, within the cluster directory, a bundle is extracted to.
+const bundleDir = "bundle"
+
+// ExportBundle writes the TKR, the images and the package repositories required to create a
+// cluster to an archive, so clusters can later be created from it without network access.
+func (t *UnmanagedCluster) ExportBundle(opts *bundle.ExportOptions) error {
+	opts.Progress = func(ref string) {
+		log.Style(outputIndent, color.Faint).Infof("%s\n", ref)
+	}
+	return bundle.Export(opts)
+}
+
+// importBundle extracts the bundle the cluster is created from into the cluster directory, loads
+// its images into docker and pushes its bundles to a registry started alongside the cluster. The
+// configuration is updated to bootstrap the cluster from the imported TKR and repositories.
+func (t *UnmanagedCluster) importBundle() error {
+	scConfig := t.config
+	dir := filepath.Join(t.clusterDirectory, bundleDir)
+
+	log.Style(outputIndent, color.Faint).Infof("Extracting %s\n", scConfig.FromBundle)
+	err := bundle.Extract(scConfig.FromBundle, dir)
+	if err != nil {
+		return err
+	}
+	m, err := bundle.ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	// Place the BOM where resolving the TKR will find it, rather than downloading it
+	bomPath, err := getUnmanagedBomPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(bomPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to make new tanzu unmanaged bom config directories %s", err)
+	}
+	bomData, err := os.ReadFile(bundle.BomPath(dir, m))
+	if err != nil {
+		return fmt.Errorf("failed to read the bundled TKR BOM. Error: %s", err.Error())
+	}
+	err = os.WriteFile(filepath.Join(bomPath, buildFilesystemSafeBomName(m.TkrLocation)), bomData, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write the bundled TKR BOM. Error: %s", err.Error())
+	}
+	if scConfig.TkrLocation != m.TkrLocation {
+		log.Style(outputIndent, color.Faint).Infof("Using TKR %s from bundle\n", m.TkrLocation)
+		scConfig.TkrLocation = m.TkrLocation
+	}
+
+	log.Style(outputIndent, color.Faint).Info("Loading images\n")
+	runtime := cluster.NewContainerRuntime(scConfig.ContainerRuntime)
+	err = bundle.LoadImages(runtime, dir, m)
+	if err != nil {
+		return err
+	}
+
+	scConfig.LocalRegistry, err = registry.Start(runtime, scConfig.ClusterName, m.RegistryImage.Ref)
+	if err != nil {
+		return err
+	}
+	log.Style(outputIndent, color.Faint).Infof("Started registry at %s\n", scConfig.LocalRegistry)
+
+	err = bundle.Push(dir, m, scConfig.LocalRegistry, func(ref string) {
+		log.Style(outputIndent, color.Faint).Infof("Pushing %s\n", ref)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.bundle = m
+	scConfig.AdditionalPackageRepos = nil
+	for _, repo := range m.AdditionalRepos {
+		scConfig.AdditionalPackageRepos = append(scConfig.AdditionalPackageRepos, t.clusterRef(repo.Relocated))
+	}
+	return nil
+}
+
+// readImportedBundle reads the manifest of the bundle that was imported when the cluster's
+// creation was first attempted.
+func (t *UnmanagedCluster) readImportedBundle() error {
+	m, err := bundle.ReadManifest(filepath.Join(t.clusterDirectory, bundleDir))
+	if err != nil {
+		return err
+	}
+	t.bundle = m
+	return nil
+}
+
+// clusterRef returns the reference, as seen from within the cluster, of a bundle that was pushed
+// to the local registry.
+func (t *UnmanagedCluster) clusterRef(hostRef string) string {
+	return registry.ClusterAddress(t.config.ClusterName) + strings.TrimPrefix(hostRef, t.config.LocalRegistry)
+}
+
+// coreRepoBundlePath returns the core package repository to install, which is served from the
+// local registry when the cluster is created from a bundle.
+func (t *UnmanagedCluster) coreRepoBundlePath() string {
+	if t.bundle != nil {
+		return t.clusterRef(t.bundle.CoreRepo.Relocated)
+	}
+	return t.bom.GetTKRCoreRepoBundlePath()
+}
+
+// bundledKappImage returns the kapp-controller bundle pushed to the local registry.
+func (t *UnmanagedCluster) bundledKappImage() (tkr.ImageReader, error) {
+	return tkr.NewTkrImageReader(t.bundle.KappBundle.Relocated)
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/cluster"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+)
+
+const (
+	calicoNodeName      = "calico-node"
+	calicoNodeNamespace = "kube-system"
+)
+
+// calicoKindOverlay configures calico-node for kind. Node containers attach to the docker network
+// through eth0, while the host may have other interfaces calico would otherwise autodetect.
+const calicoKindOverlay = `#@ load("@ytt:overlay", "overlay")
+#@overlay/match by=overlay.subset({"kind": "DaemonSet", "metadata": {"name": "calico-node"}})
+---
+spec:
+  template:
+    spec:
+      containers:
+      #@overlay/match by="name"
+      - name: calico-node
+        env:
+        #@overlay/match by="name", missing_ok=True
+        - name: IP_AUTODETECTION_METHOD
+          value: interface=eth.*
+`
+
+// isCalico returns whether the CNI of the cluster is calico.
+func isCalico(scConfig *config.UnmanagedClusterConfig) bool {
+	return strings.Contains(scConfig.Cni, "calico")
+}
+
+// defaultCalicoValues returns the calico values derived from the cluster configuration.
+func defaultCalicoValues(scConfig *config.UnmanagedClusterConfig) map[string]interface{} {
+	if scConfig.PodCidr == "" {
+		return map[string]interface{}{}
+	}
+	// calico must allocate pod addresses from the range the cluster was created with
+	return map[string]interface{}{
+		"calico": map[string]interface{}{
+			"config": map[string]interface{}{
+				"clusterCIDR": scConfig.PodCidr,
+			},
+		},
+	}
+}
+
+// cniOverlays returns the ytt overlays to install the CNI package with, if the provider needs any.
+func cniOverlays(scConfig *config.UnmanagedClusterConfig) []byte {
+	if isCalico(scConfig) && scConfig.Provider == cluster.KindClusterManagerProvider && scConfig.ExistingClusterKubeconfig == "" {
+		return []byte(calicoKindOverlay)
+	}
+	return nil
+}
+
+// waitForCalico waits for calico-node to be ready after the CNI package was installed. As the CNI is
+// installed as a package, the wait is bounded by PackageTimeout, the timeout of each package install.
+// It returns the exit code to bootstrap with when calico-node does not become ready.
+func waitForCalico(ctx context.Context, kcBytes []byte, scConfig *config.UnmanagedClusterConfig) (int, error) {
+	clientSet, err := newClientSet(kcBytes)
+	if err != nil {
+		return ErrCniInstall, fmt.Errorf("failed to load kubeconfig. Error: %s", err.Error())
+	}
+	cniTimeout, err := parseTimeout(scConfig.PackageTimeout)
+	if err != nil {
+		return InvalidConfig, err
+	}
+
+	cniCtx, cniCancel := withTimeout(ctx, cniTimeout)
+	defer cniCancel()
+	err = blockForCalicoNodeReady(cniCtx, clientSet)
+	if err != nil && cniCtx.Err() != nil {
+		return timeoutExitCode(ctx, ErrCniInstall), err
+	}
+	if err != nil {
+		return ErrCniInstall, err
+	}
+	return Success, nil
+}
+
+// blockForCalicoNodeReady waits for the calico-node DaemonSet to be ready on every node.
+func blockForCalicoNodeReady(ctx context.Context, clientSet kubernetes.Interface) error {
+	// Create the animation context and fire a go routine to animate the logging progress
+	animateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	status := make(chan string, 1)
+	go func(ctx context.Context) {
+		log.Style(outputIndent, color.Faint).AnimateProgressWithOptions(
+			logger.AnimatorWithContext(ctx),
+			logger.AnimatorWithMaxLen(maxProgressLength),
+			logger.AnimatorWithMessagef("calico-node status: %s"),
+			logger.AnimatorWithStatusChan(status),
+		)
+	}(animateCtx)
+
+	// Wait for calico-node to be ready, or the context to expire; report status into the status channel
+	var lastStatus string
+	for {
+		nodeStatus := getCalicoNodeReadiness(ctx, clientSet)
+		if ctx.Err() != nil {
+			cancel()
+			log.Style(outputIndent, color.FgYellow).ReplaceLinef("calico-node status: %s", lastStatus)
+			return fmt.Errorf("timed out waiting for calico-node to be ready. Last observed status: %s", lastStatus)
+		}
+		status <- nodeStatus
+		if nodeStatus == clusterReadyStatus {
+			cancel()
+			log.Style(outputIndent, color.Faint).ReplaceLinef("calico-node status: %s", nodeStatus)
+			return nil
+		}
+		lastStatus = nodeStatus
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+// getCalicoNodeReadiness reports how many of the calico-node pods scheduled by its DaemonSet are ready.
+// The DaemonSet may not exist yet while kapp-controller is still deploying the package.
+func getCalicoNodeReadiness(ctx context.Context, clientSet kubernetes.Interface) string {
+	ds, err := clientSet.AppsV1().DaemonSets(calicoNodeNamespace).Get(ctx, calicoNodeName, metav1.GetOptions{})
+	if err != nil {
+		return "Waiting for DaemonSet"
+	}
+
+	desired := ds.Status.DesiredNumberScheduled
+	if desired > 0 && ds.Status.NumberReady == desired && ds.Status.UpdatedNumberScheduled == desired {
+		return clusterReadyStatus
+	}
+	return fmt.Sprintf("%d/%d pods Ready", ds.Status.NumberReady, desired)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const checkpointFileName = "checkpoint.yaml"
+
+// phase is a step of the bootstrapping process whose completion is recorded in the
+// cluster directory. Completed phases are skipped when cluster creation is resumed.
+type phase string
+
+const (
+	phaseBundleImported    phase = "BundleImported"
+	phaseTkrResolved       phase = "TkrResolved"
+	phaseClusterCreated    phase = "ClusterCreated"
+	phaseKappInstalled     phase = "KappControllerInstalled"
+	phaseReposReconciled   phase = "PackageReposReconciled"
+	phaseCniInstalled      phase = "CniInstalled"
+	phasePackagesInstalled phase = "PackagesInstalled"
+	phaseKubeconfigMerged  phase = "KubeconfigMerged"
+)
+
+// checkpoint tracks the bootstrapping phases that have completed for a cluster.
+type checkpoint struct {
+	filePath        string
+	CompletedPhases []phase `yaml:"CompletedPhases"`
+}
+
+// newCheckpoint returns an empty checkpoint that will be persisted in the cluster directory.
+func newCheckpoint(clusterDir string) *checkpoint {
+	return &checkpoint{
+		filePath: filepath.Join(clusterDir, checkpointFileName),
+	}
+}
+
+// readCheckpoint loads the checkpoint stored in the cluster directory. If no
+// checkpoint has been recorded yet, an empty checkpoint is returned.
+func readCheckpoint(clusterDir string) (*checkpoint, error) {
+	cp := newCheckpoint(clusterDir)
+
+	data, err := os.ReadFile(cp.filePath)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed reading checkpoint file. Error: %s", err.Error())
+	}
+
+	err = yaml.Unmarshal(data, cp)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint at %s was invalid. Error: %s", cp.filePath, err.Error())
+	}
+
+	return cp, nil
+}
+
+// completed returns whether the phase has already been recorded.
+func (c *checkpoint) completed(p phase) bool {
+	for _, completedPhase := range c.CompletedPhases {
+		if completedPhase == p {
+			return true
+		}
+	}
+	return false
+}
+
+// record marks the phase as completed and persists the checkpoint to disk.
+func (c *checkpoint) record(p phase) error {
+	if c.completed(p) {
+		return nil
+	}
+	c.CompletedPhases = append(c.CompletedPhases, p)
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to render checkpoint. Error: %s", err.Error())
+	}
+	err = os.WriteFile(c.filePath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint file. Error: %s", err.Error())
+	}
+
+	return nil
+}
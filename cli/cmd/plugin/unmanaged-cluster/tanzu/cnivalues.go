@@ -0,0 +1,167 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+// defaultCNIValues returns the values the CNI package is installed with before the
+// CniConfiguration from the config is applied.
+func defaultCNIValues(scConfig *config.UnmanagedClusterConfig) map[string]interface{} {
+	values := map[string]interface{}{}
+	if isAntrea(scConfig) {
+		// Antrea needs to know it runs on docker to work around its checksum offloading
+		values["infraProvider"] = "docker"
+	}
+	if isCalico(scConfig) {
+		values = mergeValues(values, defaultCalicoValues(scConfig))
+	}
+	return mergeValues(values, ipFamilyCNIValues(scConfig))
+}
+
+// isAntrea returns whether the CNI of the cluster is antrea.
+func isAntrea(scConfig *config.UnmanagedClusterConfig) bool {
+	return strings.Contains(scConfig.Cni, "antrea")
+}
+
+// buildCNIValues renders the values for the CNI package install. The CniConfiguration from the
+// config is merged over the defaults and validated against the values schema of the package, when
+// the package provides one. Nil is returned when there are no values to configure.
+func buildCNIValues(scConfig *config.UnmanagedClusterConfig, valuesSchema []byte) ([]byte, error) {
+	values := mergeValues(defaultCNIValues(scConfig), scConfig.CNIConfiguration)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	if len(valuesSchema) != 0 {
+		schema := map[string]interface{}{}
+		err := yaml.Unmarshal(valuesSchema, &schema)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse values schema of the CNI package. Error: %s", err.Error())
+		}
+		problems := validateValues(values, schema, "")
+		if len(problems) != 0 {
+			return nil, fmt.Errorf("CniConfiguration is invalid: %s", strings.Join(problems, "; "))
+		}
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render CNI values. Error: %s", err.Error())
+	}
+	return data, nil
+}
+
+// mergeValues returns a copy of base with override merged over it. Nested maps are merged key by
+// key, all other values in override replace those in base.
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		baseMap, baseIsMap := merged[k].(map[string]interface{})
+		overrideMap, overrideIsMap := v.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[k] = mergeValues(baseMap, overrideMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// validateValues checks values against an OpenAPI v3 schema, returning a description of each
+// problem found. Keys that are not declared in the properties of an object are rejected, unless the
+// object allows additionalProperties.
+func validateValues(value interface{}, schema map[string]interface{}, path string) []string {
+	var problems []string
+	schemaType, _ := schema["type"].(string)
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schemaType == "" {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s must be of type %s", describePath(path), schemaType)}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schemaType != "" && schemaType != "object" {
+			return []string{fmt.Sprintf("%s must be of type %s", describePath(path), schemaType)}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		additional := schema["additionalProperties"]
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if propSchema, ok := properties[k].(map[string]interface{}); ok {
+				problems = append(problems, validateValues(v[k], propSchema, childPath)...)
+				continue
+			}
+			switch a := additional.(type) {
+			case map[string]interface{}:
+				problems = append(problems, validateValues(v[k], a, childPath)...)
+			case bool:
+				if !a {
+					problems = append(problems, fmt.Sprintf("%s is not a known key", childPath))
+				}
+			default:
+				if properties != nil {
+					problems = append(problems, fmt.Sprintf("%s is not a known key", childPath))
+				}
+			}
+		}
+	case []interface{}:
+		if schemaType != "" && schemaType != "array" {
+			return []string{fmt.Sprintf("%s must be of type %s", describePath(path), schemaType)}
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				problems = append(problems, validateValues(item, items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case string:
+		if schemaType != "" && schemaType != "string" {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", describePath(path), schemaType))
+		}
+	case bool:
+		if schemaType != "" && schemaType != "boolean" {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", describePath(path), schemaType))
+		}
+	case int, int64, uint64:
+		if schemaType != "" && schemaType != "integer" && schemaType != "number" {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", describePath(path), schemaType))
+		}
+	case float64:
+		if schemaType != "" && schemaType != "number" && !(schemaType == "integer" && v == float64(int64(v))) {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", describePath(path), schemaType))
+		}
+	}
+
+	return problems
+}
+
+// describePath returns a readable name for a path of values, where an empty path is the root.
+func describePath(path string) string {
+	if path == "" {
+		return "values"
+	}
+	return path
+}
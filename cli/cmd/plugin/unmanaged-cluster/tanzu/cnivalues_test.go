@@ -0,0 +1,159 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+const antreaValuesSchema = `{
+  "type": "object",
+  "properties": {
+    "infraProvider": {"type": "string"},
+    "antrea": {
+      "type": "object",
+      "properties": {
+        "config": {
+          "type": "object",
+          "properties": {
+            "trafficEncapMode": {"type": "string"},
+            "featureGates": {
+              "type": "object",
+              "properties": {
+                "AntreaProxy": {"type": "boolean"},
+                "FlowExporter": {"type": "boolean"}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestBuildCNIValuesMergesOverDefaults(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni: "antrea",
+		CNIConfiguration: map[string]interface{}{
+			"antrea": map[string]interface{}{
+				"config": map[string]interface{}{
+					"featureGates": map[string]interface{}{
+						"FlowExporter": true,
+					},
+				},
+			},
+		},
+	}
+
+	data, err := buildCNIValues(scConfig, []byte(antreaValuesSchema))
+	if err != nil {
+		t.Fatalf("expected values to be valid, got error: %s", err.Error())
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		t.Fatalf("unable to parse rendered values: %s", err.Error())
+	}
+	if values["infraProvider"] != "docker" {
+		t.Errorf("expected default infraProvider to be kept, was: %v", values["infraProvider"])
+	}
+	featureGates := values["antrea"].(map[string]interface{})["config"].(map[string]interface{})["featureGates"].(map[string]interface{})
+	if featureGates["FlowExporter"] != true {
+		t.Errorf("expected FlowExporter feature gate to be set, was: %v", featureGates["FlowExporter"])
+	}
+}
+
+func TestBuildCNIValuesOverridesDefaults(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni: "antrea",
+		CNIConfiguration: map[string]interface{}{
+			"infraProvider": "vsphere",
+		},
+	}
+
+	data, err := buildCNIValues(scConfig, nil)
+	if err != nil {
+		t.Fatalf("expected values to be valid, got error: %s", err.Error())
+	}
+	if !strings.Contains(string(data), "infraProvider: vsphere") {
+		t.Errorf("expected infraProvider to be overridden, values were: %s", string(data))
+	}
+}
+
+func TestBuildCNIValuesRejectsUnknownKeys(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni: "antrea",
+		CNIConfiguration: map[string]interface{}{
+			"antrea": map[string]interface{}{
+				"config": map[string]interface{}{
+					"featureGates": map[string]interface{}{
+						"NotAGate": true,
+					},
+					"trafficEncapMode": 5,
+				},
+			},
+		},
+	}
+
+	_, err := buildCNIValues(scConfig, []byte(antreaValuesSchema))
+	if err == nil {
+		t.Fatal("expected invalid CniConfiguration to be rejected")
+	}
+	if !strings.Contains(err.Error(), "antrea.config.featureGates.NotAGate is not a known key") {
+		t.Errorf("expected unknown key to be reported, got: %s", err.Error())
+	}
+	if !strings.Contains(err.Error(), "antrea.config.trafficEncapMode must be of type string") {
+		t.Errorf("expected wrong type to be reported, got: %s", err.Error())
+	}
+}
+
+func TestBuildCNIValuesEmpty(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni: "calico",
+	}
+
+	data, err := buildCNIValues(scConfig, []byte(antreaValuesSchema))
+	if err != nil {
+		t.Fatalf("expected no error, got: %s", err.Error())
+	}
+	if data != nil {
+		t.Errorf("expected no values, got: %s", string(data))
+	}
+}
+
+func TestBuildCNIValuesCalicoPodCidr(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni:     "calico",
+		PodCidr: "10.244.0.0/16",
+		CNIConfiguration: map[string]interface{}{
+			"calico": map[string]interface{}{
+				"config": map[string]interface{}{
+					"vethMTU": "1440",
+				},
+			},
+		},
+	}
+
+	data, err := buildCNIValues(scConfig, nil)
+	if err != nil {
+		t.Fatalf("expected values to be valid, got error: %s", err.Error())
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		t.Fatalf("unable to parse rendered values: %s", err.Error())
+	}
+	calicoConfig := values["calico"].(map[string]interface{})["config"].(map[string]interface{})
+	if calicoConfig["clusterCIDR"] != "10.244.0.0/16" {
+		t.Errorf("expected clusterCIDR to match PodCidr, was: %v", calicoConfig["clusterCIDR"])
+	}
+	if calicoConfig["vethMTU"] != "1440" {
+		t.Errorf("expected vethMTU from CniConfiguration, was: %v", calicoConfig["vethMTU"])
+	}
+}
@@ -48,4 +48,22 @@ const (
 
 	// 13 - Failed to merge kubeconfig and set context
 	ErrKubeconfigContextSet
+
+	// 14 - Could not read the state of a cluster to resume its creation
+	ErrResumeCluster
+
+	// 15 - Bootstrapping did not complete within the configured timeout
+	ErrTimeout
+
+	// 16 - Timed out waiting for kapp controller to be running
+	ErrKappTimeout
+
+	// 17 - Timed out waiting for the core package repo to reconcile
+	ErrRepoTimeout
+
+	// 18 - Could not install a package from the config, or it did not reconcile
+	ErrPackageInstall
+
+	// 19 - Could not import the bundle the cluster is created from
+	ErrBundleImport
 )
@@ -0,0 +1,96 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/events"
+)
+
+// phaseBootstrap is reported as an event but is not recorded in the checkpoint. It spans the entire
+// bootstrap, from reading the configuration to the final result.
+const phaseBootstrap phase = "Bootstrap"
+
+// Option is an option to be passed to New when creating a Manager.
+type Option interface {
+	apply(*UnmanagedCluster)
+}
+
+type optionAdapter func(*UnmanagedCluster)
+
+func (o optionAdapter) apply(t *UnmanagedCluster) {
+	o(t)
+}
+
+// WithEventEmitter sets the emitter used to publish machine-readable progress events while
+// bootstrapping a cluster.
+// Ex: New(log, WithEventEmitter(events.NewJSONEmitter(os.Stdout)))
+func WithEventEmitter(e events.Emitter) Option {
+	return optionAdapter(func(t *UnmanagedCluster) {
+		t.events = e
+	})
+}
+
+// emit publishes an event for a bootstrapping phase.
+func (t *UnmanagedCluster) emit(p phase, status events.Status, details map[string]string) {
+	t.events.Emit(events.Event{
+		Cluster: t.clusterName(),
+		Phase:   string(p),
+		Status:  status,
+		Details: details,
+	})
+}
+
+// startPhase marks the phase as the one currently running and emits its start event.
+func (t *UnmanagedCluster) startPhase(p phase) {
+	t.currentPhase = p
+	t.emit(p, events.StatusStarted, nil)
+}
+
+// finishPhase emits the successful completion of the phase. Details describe what the phase
+// resolved, such as images or status strings.
+func (t *UnmanagedCluster) finishPhase(p phase, details map[string]string) {
+	t.currentPhase = ""
+	t.emit(p, events.StatusSucceeded, details)
+}
+
+// skipPhase emits that the phase did not run because it completed during a previous bootstrap.
+func (t *UnmanagedCluster) skipPhase(p phase) {
+	t.emit(p, events.StatusSkipped, nil)
+}
+
+// failPhase emits the failure of the phase along with the exit code it resulted in. An exit code
+// of 0 represents a failure that does not stop the bootstrap.
+func (t *UnmanagedCluster) failPhase(p phase, exitCode int, err error) {
+	t.currentPhase = ""
+	t.events.Emit(events.Event{
+		Cluster:  t.clusterName(),
+		Phase:    string(p),
+		Status:   events.StatusFailed,
+		ExitCode: exitCode,
+		Error:    err.Error(),
+	})
+}
+
+// finishBootstrap emits the result of the bootstrap. When it failed, the phase that was running
+// is reported as failed as well.
+func (t *UnmanagedCluster) finishBootstrap(exitCode int, err error) {
+	if err == nil {
+		t.finishPhase(phaseBootstrap, map[string]string{
+			"kubeconfig": t.config.KubeconfigPath,
+		})
+		return
+	}
+	if t.currentPhase != "" {
+		t.failPhase(t.currentPhase, exitCode, err)
+	}
+	t.failPhase(phaseBootstrap, exitCode, err)
+}
+
+// clusterName returns the name of the cluster being bootstrapped, if it is known yet.
+func (t *UnmanagedCluster) clusterName() string {
+	if t.config == nil {
+		return ""
+	}
+	return t.config.ClusterName
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/cluster"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+// validateIPFamily makes sure the IP family is known and that the pod and service CIDRs are of
+// that family. Clusters that are not IPv4 only are given the default CIDRs of their family when
+// the IPv4 defaults are still set.
+func validateIPFamily(scConfig *config.UnmanagedClusterConfig) error {
+	if scConfig.IPFamily == "" {
+		scConfig.IPFamily = config.IPFamilyIPv4
+	}
+	switch scConfig.IPFamily {
+	case config.IPFamilyIPv4:
+	case config.IPFamilyIPv6, config.IPFamilyDual:
+		if scConfig.Provider != cluster.KindClusterManagerProvider {
+			return fmt.Errorf("%s %s is only supported by the %s provider", config.IPFamily, scConfig.IPFamily, cluster.KindClusterManagerProvider)
+		}
+		ipv4PodCidr, ipv4ServiceCidr := config.DefaultCIDRs(config.IPFamilyIPv4)
+		podCidr, serviceCidr := config.DefaultCIDRs(scConfig.IPFamily)
+		if scConfig.PodCidr == ipv4PodCidr {
+			scConfig.PodCidr = podCidr
+		}
+		if scConfig.ServiceCidr == ipv4ServiceCidr {
+			scConfig.ServiceCidr = serviceCidr
+		}
+	default:
+		return fmt.Errorf("unknown %s %q, expected one of: %s, %s, %s", config.IPFamily, scConfig.IPFamily,
+			config.IPFamilyIPv4, config.IPFamilyIPv6, config.IPFamilyDual)
+	}
+
+	cidrs := []struct {
+		name  string
+		value string
+	}{
+		{config.PodCIDR, scConfig.PodCidr},
+		{config.ServiceCIDR, scConfig.ServiceCidr},
+	}
+	for _, cidr := range cidrs {
+		if cidr.value == "" {
+			continue
+		}
+		err := validateCIDRFamily(cidr.value, scConfig.IPFamily)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q. Error: %s", cidr.name, cidr.value, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateCIDRFamily checks a comma separated list of CIDRs holds a single CIDR of an ipv4 or
+// ipv6 family, or an IPv4 and an IPv6 CIDR for the dual family.
+func validateCIDRFamily(value, ipFamily string) error {
+	ipv4, ipv6, err := splitCIDRs(value)
+	if err != nil {
+		return err
+	}
+
+	switch ipFamily {
+	case config.IPFamilyIPv4:
+		if len(ipv4) != 1 || len(ipv6) != 0 {
+			return fmt.Errorf("%s %s expects a single IPv4 CIDR", config.IPFamily, ipFamily)
+		}
+	case config.IPFamilyIPv6:
+		if len(ipv4) != 0 || len(ipv6) != 1 {
+			return fmt.Errorf("%s %s expects a single IPv6 CIDR", config.IPFamily, ipFamily)
+		}
+	case config.IPFamilyDual:
+		if len(ipv4) != 1 || len(ipv6) != 1 {
+			return fmt.Errorf("%s %s expects an IPv4 and an IPv6 CIDR, separated by a comma", config.IPFamily, ipFamily)
+		}
+	}
+	return nil
+}
+
+// splitCIDRs parses a comma separated list of CIDRs and returns them by family.
+func splitCIDRs(value string) (ipv4, ipv6 []string, err error) {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		ip, _, parseErr := net.ParseCIDR(entry)
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("%q is not a CIDR", entry)
+		}
+		if ip.To4() != nil {
+			ipv4 = append(ipv4, entry)
+		} else {
+			ipv6 = append(ipv6, entry)
+		}
+	}
+	return ipv4, ipv6, nil
+}
+
+// ipFamilyCNIValues returns the CNI values that configure the CNI for a cluster that is not IPv4
+// only. calico already allocates pods from PodCidr, but must be told which families to assign,
+// while antrea must be told the service CIDR of each family.
+func ipFamilyCNIValues(scConfig *config.UnmanagedClusterConfig) map[string]interface{} {
+	if scConfig.IPFamily != config.IPFamilyIPv6 && scConfig.IPFamily != config.IPFamilyDual {
+		return map[string]interface{}{}
+	}
+
+	if isCalico(scConfig) {
+		ipFamily := config.IPFamilyIPv6
+		if scConfig.IPFamily == config.IPFamilyDual {
+			ipFamily = config.IPFamilyIPv4 + "," + config.IPFamilyIPv6
+		}
+		return map[string]interface{}{
+			"ipFamily": ipFamily,
+		}
+	}
+
+	if isAntrea(scConfig) && scConfig.ServiceCidr != "" {
+		ipv4, ipv6, err := splitCIDRs(scConfig.ServiceCidr)
+		if err != nil {
+			return map[string]interface{}{}
+		}
+		antreaConfig := map[string]interface{}{}
+		if len(ipv4) != 0 {
+			antreaConfig["serviceCIDR"] = ipv4[0]
+		}
+		if len(ipv6) != 0 {
+			antreaConfig["serviceCIDRv6"] = ipv6[0]
+		}
+		return map[string]interface{}{
+			"antrea": map[string]interface{}{
+				"config": antreaConfig,
+			},
+		}
+	}
+	return map[string]interface{}{}
+}
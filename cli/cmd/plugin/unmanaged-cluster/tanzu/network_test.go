@@ -0,0 +1,101 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+func TestValidateIPFamilyDefaultsCIDRs(t *testing.T) {
+	ipv4PodCidr, ipv4ServiceCidr := config.DefaultCIDRs(config.IPFamilyIPv4)
+
+	for _, ipFamily := range []string{config.IPFamilyIPv6, config.IPFamilyDual} {
+		scConfig := &config.UnmanagedClusterConfig{
+			Provider:    "kind",
+			IPFamily:    ipFamily,
+			PodCidr:     ipv4PodCidr,
+			ServiceCidr: ipv4ServiceCidr,
+		}
+		if err := validateIPFamily(scConfig); err != nil {
+			t.Fatalf("expected %s to be valid with the default CIDRs, got error: %s", ipFamily, err.Error())
+		}
+		podCidr, serviceCidr := config.DefaultCIDRs(ipFamily)
+		if scConfig.PodCidr != podCidr || scConfig.ServiceCidr != serviceCidr {
+			t.Errorf("expected the %s default CIDRs, got %s and %s", ipFamily, scConfig.PodCidr, scConfig.ServiceCidr)
+		}
+	}
+
+	scConfig := &config.UnmanagedClusterConfig{Provider: "kind", PodCidr: ipv4PodCidr, ServiceCidr: ipv4ServiceCidr}
+	if err := validateIPFamily(scConfig); err != nil {
+		t.Fatalf("expected no IP family to be valid, got error: %s", err.Error())
+	}
+	if scConfig.IPFamily != config.IPFamilyIPv4 || scConfig.PodCidr != ipv4PodCidr {
+		t.Errorf("expected an IPv4 cluster, got %s with pod CIDR %s", scConfig.IPFamily, scConfig.PodCidr)
+	}
+}
+
+func TestValidateIPFamilyRejectsMismatchedCIDRs(t *testing.T) {
+	tests := []struct {
+		ipFamily    string
+		podCidr     string
+		serviceCidr string
+		errContains string
+	}{
+		{config.IPFamilyIPv4, "fd00:10:244::/56", "10.96.0.0/16", "single IPv4 CIDR"},
+		{config.IPFamilyIPv6, "fd00:10:244::/56", "10.96.0.0/12", "single IPv6 CIDR"},
+		{config.IPFamilyDual, "10.245.0.0/16", "10.96.0.0/16,fd00:10:96::/112", "an IPv4 and an IPv6 CIDR"},
+		{config.IPFamilyDual, "10.244.0.0/16,10.245.0.0/16", "10.96.0.0/16,fd00:10:96::/112", "an IPv4 and an IPv6 CIDR"},
+		{config.IPFamilyDual, "10.244.0.0/16,fd00:10:244::", "10.96.0.0/16,fd00:10:96::/112", "is not a CIDR"},
+		{"ipv5", "10.244.0.0/16", "10.96.0.0/16", "unknown IpFamily"},
+	}
+
+	for _, tt := range tests {
+		scConfig := &config.UnmanagedClusterConfig{
+			Provider:    "kind",
+			IPFamily:    tt.ipFamily,
+			PodCidr:     tt.podCidr,
+			ServiceCidr: tt.serviceCidr,
+		}
+		err := validateIPFamily(scConfig)
+		if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+			t.Errorf("expected an error containing %q for %s %s %s, got: %v", tt.errContains, tt.ipFamily, tt.podCidr, tt.serviceCidr, err)
+		}
+	}
+
+	scConfig := &config.UnmanagedClusterConfig{Provider: "k3d", IPFamily: config.IPFamilyDual}
+	if err := validateIPFamily(scConfig); err == nil {
+		t.Error("expected an error for a dual-stack cluster with the k3d provider")
+	}
+}
+
+func TestDefaultCNIValuesIPFamily(t *testing.T) {
+	podCidr, serviceCidr := config.DefaultCIDRs(config.IPFamilyDual)
+	scConfig := &config.UnmanagedClusterConfig{
+		Cni:         "calico",
+		IPFamily:    config.IPFamilyDual,
+		PodCidr:     podCidr,
+		ServiceCidr: serviceCidr,
+	}
+	values := defaultCNIValues(scConfig)
+	if values["ipFamily"] != "ipv4,ipv6" {
+		t.Errorf("expected calico to assign both IP families, was: %v", values["ipFamily"])
+	}
+
+	scConfig.Cni = "antrea"
+	values = defaultCNIValues(scConfig)
+	antreaValues, _ := values["antrea"].(map[string]interface{})
+	antreaConfig, _ := antreaValues["config"].(map[string]interface{})
+	if antreaConfig["serviceCIDR"] != "10.96.0.0/16" || antreaConfig["serviceCIDRv6"] != "fd00:10:96::/112" {
+		t.Errorf("expected antrea to be given both service CIDRs, was: %v", antreaConfig)
+	}
+
+	scConfig.IPFamily = config.IPFamilyIPv4
+	values = defaultCNIValues(scConfig)
+	if _, ok := values["antrea"]; ok {
+		t.Errorf("expected no antrea config for an IPv4 cluster, was: %v", values)
+	}
+}
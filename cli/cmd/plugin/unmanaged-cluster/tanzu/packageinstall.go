@@ -0,0 +1,297 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/packages"
+)
+
+const (
+	defaultPackageNamespace = "default"
+	// latestVersionConstraint is the semver constraint used when no package version is given.
+	// kapp-controller selects the highest version satisfying the constraint.
+	latestVersionConstraint    = ">0.0.0"
+	packageReconcileSucceeded  = "Reconcile succeeded"
+	packageReconcileFailedText = "Reconcile failed"
+)
+
+// validatePackages sets the defaults of the packages to install and makes sure they can be
+// installed in an order that satisfies their dependencies.
+func validatePackages(pkgs []config.Package) error {
+	for i := range pkgs {
+		pkg := &pkgs[i]
+		if pkg.Name == "" {
+			return fmt.Errorf("package %d does not have a Name", i+1)
+		}
+		if pkg.Namespace == "" {
+			pkg.Namespace = defaultPackageNamespace
+		}
+		if pkg.InstallName == "" {
+			pkg.InstallName = strings.Split(pkg.Name, ".")[0]
+		}
+		if pkg.ValuesFile != "" {
+			if len(pkg.Values) != 0 {
+				return fmt.Errorf("package %s sets both Values and ValuesFile, only one may be set", pkg.InstallName)
+			}
+			// Resolve the file now, so the saved configuration still points to it when resuming
+			valuesFile, err := filepath.Abs(pkg.ValuesFile)
+			if err != nil {
+				return fmt.Errorf("unable to resolve ValuesFile of package %s. Error: %s", pkg.InstallName, err.Error())
+			}
+			pkg.ValuesFile = valuesFile
+		}
+	}
+
+	_, err := orderPackages(pkgs)
+	return err
+}
+
+// orderPackages returns the packages in the order they must be installed. Packages are installed in
+// the order listed, except a package is always installed after the packages it depends on.
+func orderPackages(pkgs []config.Package) ([]config.Package, error) {
+	byInstallName := map[string]int{}
+	for i := range pkgs {
+		if _, ok := byInstallName[pkgs[i].InstallName]; ok {
+			return nil, fmt.Errorf("more than one package uses the InstallName %s", pkgs[i].InstallName)
+		}
+		byInstallName[pkgs[i].InstallName] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(pkgs))
+	ordered := make([]config.Package, 0, len(pkgs))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("packages have a circular dependency: %s", strings.Join(append(path, pkgs[i].InstallName), " -> "))
+		}
+		state[i] = visiting
+		path = append(path, pkgs[i].InstallName)
+		for _, dep := range pkgs[i].DependsOn {
+			j, ok := byInstallName[dep]
+			if !ok {
+				return fmt.Errorf("package %s depends on %s, which is not a package in the configuration", pkgs[i].InstallName, dep)
+			}
+			if err := visit(j, path); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		ordered = append(ordered, pkgs[i])
+		return nil
+	}
+
+	for i := range pkgs {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// installPackages installs the packages from the configuration, waiting for each to reconcile
+// before installing the next. It returns the status of each package by install name.
+func installPackages(ctx context.Context, pkgClient packages.PackageManager, t *UnmanagedCluster) (map[string]string, int, error) {
+	pkgs, err := orderPackages(t.config.Packages)
+	if err != nil {
+		return nil, InvalidConfig, err
+	}
+	pkgTimeout, err := parseTimeout(t.config.PackageTimeout)
+	if err != nil {
+		return nil, InvalidConfig, err
+	}
+
+	details := map[string]string{}
+	for i := range pkgs {
+		pkg := &pkgs[i]
+		log.Style(outputIndent, color.Faint).Infof("%s (%s)\n", pkg.Name, pkg.InstallName)
+		err = installPackage(pkgClient, pkg)
+		if err != nil {
+			return nil, ErrPackageInstall, fmt.Errorf("failed to install package %s. Error: %s", pkg.InstallName, err.Error())
+		}
+
+		pkgCtx, pkgCancel := withTimeout(ctx, pkgTimeout)
+		err = blockForPackageInstallStatus(pkgCtx, pkgClient, pkg)
+		timedOut := pkgCtx.Err() != nil
+		pkgCancel()
+		if err != nil && timedOut {
+			return nil, timeoutExitCode(ctx, ErrPackageInstall), err
+		}
+		if err != nil {
+			return nil, ErrPackageInstall, err
+		}
+		details[pkg.InstallName] = packageReconcileSucceeded
+	}
+
+	return details, Success, nil
+}
+
+// installPackage creates the PackageInstall for a package, along with the namespace and service
+// account it is installed with. A PackageInstall left behind by a previous bootstrap is reused.
+func installPackage(pkgClient packages.PackageManager, pkg *config.Package) error {
+	svcAcct, err := pkgClient.CreateRootServiceAccount(pkg.Namespace, tkgSvcAcctName)
+	if err != nil {
+		return fmt.Errorf("failed to create service account. Error: %s", err.Error())
+	}
+	fqPkgName, err := resolvePackageName(pkgClient, pkg.Namespace, pkg.Name)
+	if err != nil {
+		return err
+	}
+	values, err := packageValues(pkg)
+	if err != nil {
+		return err
+	}
+
+	version := pkg.Version
+	if version == "" {
+		version = latestVersionConstraint
+	}
+	installOpts := packages.PackageInstallOpts{
+		Namespace:      pkg.Namespace,
+		InstallName:    pkg.InstallName,
+		FqPkgName:      fqPkgName,
+		Version:        version,
+		Configuration:  values,
+		ServiceAccount: svcAcct.Name,
+	}
+	_, err = pkgClient.CreatePackageInstall(&installOpts)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// resolvePackageName returns the fully qualified name of a package available in the namespace, which
+// includes the packages of repositories in the global package namespace. The name may be fully
+// qualified already, or be the first part of the name, such as cert-manager.
+func resolvePackageName(pkgClient packages.PackageManager, ns, name string) (string, error) {
+	namespaces := []string{ns}
+	if ns != tkgGlobalPkgNamespace {
+		namespaces = append(namespaces, tkgGlobalPkgNamespace)
+	}
+
+	var matches []string
+	for _, namespace := range namespaces {
+		pkgs, err := pkgClient.ListPackagesInNamespace(namespace)
+		if err != nil {
+			return "", err
+		}
+		for i := range pkgs {
+			refName := pkgs[i].Spec.RefName
+			if refName == name {
+				return refName, nil
+			}
+			if strings.HasPrefix(refName, name+".") && !containsString(matches, refName) {
+				matches = append(matches, refName)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no package named %s is available in the package repositories", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("package name %s is ambiguous, use one of: %s", name, strings.Join(matches, ", "))
+	}
+}
+
+// packageValues returns the values to install the package with, if any were configured.
+func packageValues(pkg *config.Package) ([]byte, error) {
+	if pkg.ValuesFile != "" {
+		values, err := os.ReadFile(pkg.ValuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ValuesFile. Error: %s", err.Error())
+		}
+		return values, nil
+	}
+	if len(pkg.Values) == 0 {
+		return nil, nil
+	}
+
+	values, err := yaml.Marshal(pkg.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Values. Error: %s", err.Error())
+	}
+	return values, nil
+}
+
+func blockForPackageInstallStatus(ctx context.Context, pkgClient packages.PackageManager, pkg *config.Package) error {
+	// Create the animation context and fire a go routine to animate the logging progress
+	animateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	status := make(chan string, 1)
+	go func(ctx context.Context) {
+		log.Style(outputIndent, color.Reset).AnimateProgressWithOptions(
+			logger.AnimatorWithContext(ctx),
+			logger.AnimatorWithMaxLen(maxProgressLength),
+			logger.AnimatorWithMessagef(pkg.InstallName+" package status: %s"),
+			logger.AnimatorWithStatusChan(status),
+		)
+	}(animateCtx)
+
+	// Wait for the package to reconcile, or the context to expire; report status into the status channel
+	var lastStatus string
+	for {
+		pkgStatus, err := pkgClient.GetPackageInstallStatus(ctx, pkg.Namespace, pkg.InstallName)
+		if ctx.Err() != nil {
+			cancel()
+			log.Style(outputIndent, color.FgYellow).ReplaceLinef("%s package status: %s", pkg.InstallName, lastStatus)
+			return fmt.Errorf("timed out waiting for package %s to reconcile. Last observed status: %s", pkg.InstallName, lastStatus)
+		}
+		status <- pkgStatus
+		if err != nil {
+			cancel()
+			return err
+		}
+		if pkgStatus == packageReconcileSucceeded {
+			cancel()
+			log.Style(outputIndent, color.Faint).ReplaceLinef("%s package status: %s", pkg.InstallName, pkgStatus)
+			return nil
+		}
+		if strings.HasPrefix(pkgStatus, packageReconcileFailedText) {
+			cancel()
+			log.Style(outputIndent, color.FgRed).ReplaceLinef("%s package status: %s", pkg.InstallName, pkgStatus)
+			return fmt.Errorf("package %s failed to reconcile. Status: %s", pkg.InstallName, pkgStatus)
+		}
+		lastStatus = pkgStatus
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
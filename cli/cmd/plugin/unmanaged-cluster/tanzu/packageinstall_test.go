@@ -0,0 +1,94 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	packaging "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	datapackaging "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/packages"
+)
+
+// fakePackageManager serves the packages of each namespace, and records the updates made to package
+// repositories and installs. Methods that are not overridden panic.
+type fakePackageManager struct {
+	packages.PackageManager
+	packages map[string][]string
+	// versions are the versions of packages, by their fully qualified name.
+	versions map[string]string
+	// repoStatus and installStatus are reported for every package repository and install.
+	repoStatus    string
+	installStatus string
+	// repoURLs and installVersions record the updates made, by namespace and name.
+	repoURLs        map[string]string
+	installVersions map[string]string
+}
+
+func (f *fakePackageManager) ListPackagesInNamespace(ns string) ([]datapackaging.Package, error) {
+	pkgs := []datapackaging.Package{}
+	for _, refName := range f.packages[ns] {
+		pkgs = append(pkgs, datapackaging.Package{Spec: datapackaging.PackageSpec{RefName: refName, Version: f.versions[refName]}})
+	}
+	return pkgs, nil
+}
+
+func (f *fakePackageManager) UpdatePackageRepo(ns, name, url string) (*packaging.PackageRepository, error) {
+	if f.repoURLs == nil {
+		f.repoURLs = map[string]string{}
+	}
+	f.repoURLs[ns+"/"+name] = url
+	return &packaging.PackageRepository{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}, nil
+}
+
+func (f *fakePackageManager) GetRepositoryStatus(ctx context.Context, ns, name string) (string, error) {
+	return f.repoStatus, nil
+}
+
+func (f *fakePackageManager) UpdatePackageInstallVersion(ns, name, version string) (*packaging.PackageInstall, error) {
+	if f.installVersions == nil {
+		f.installVersions = map[string]string{}
+	}
+	f.installVersions[ns+"/"+name] = version
+	return &packaging.PackageInstall{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}, nil
+}
+
+func (f *fakePackageManager) GetPackageInstallStatus(ctx context.Context, ns, name string) (string, error) {
+	return f.installStatus, nil
+}
+
+func TestResolvePackageName(t *testing.T) {
+	pkgClient := &fakePackageManager{packages: map[string][]string{
+		"apps":                {"cert-manager.community.tanzu.vmware.com"},
+		tkgGlobalPkgNamespace: {"contour.community.tanzu.vmware.com", "contour.example.com"},
+	}}
+
+	tests := []struct {
+		name        string
+		expected    string
+		errContains string
+	}{
+		{"cert-manager", "cert-manager.community.tanzu.vmware.com", ""},
+		{"contour.example.com", "contour.example.com", ""},
+		{"contour", "", "ambiguous"},
+		{"harbor", "", "no package named harbor"},
+	}
+
+	for _, tt := range tests {
+		refName, err := resolvePackageName(pkgClient, "apps", tt.name)
+		if tt.errContains != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected an error containing %q for %s, got: %v", tt.errContains, tt.name, err)
+			}
+			continue
+		}
+		if err != nil || refName != tt.expected {
+			t.Errorf("expected %s to resolve to %s, got %q, error: %v", tt.name, tt.expected, refName, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"strings"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/registry"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
+)
+
+// clusterDomain is the DNS domain of services within the cluster.
+const clusterDomain = "cluster.local"
+
+// proxyEnabled returns whether requests are sent through a proxy.
+func proxyEnabled(scConfig *config.UnmanagedClusterConfig) bool {
+	return scConfig.HTTPProxy != "" || scConfig.HTTPSProxy != ""
+}
+
+// noProxyList returns the configured NoProxy along with the addresses that are always reached
+// directly: the loopback interface, the pod and service CIDRs, the cluster domain and the local
+// registry.
+func noProxyList(scConfig *config.UnmanagedClusterConfig) string {
+	var noProxy []string
+	for _, entry := range strings.Split(scConfig.NoProxy, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			noProxy = append(noProxy, entry)
+		}
+	}
+
+	noProxy = append(noProxy, "localhost", "127.0.0.1")
+	for _, entry := range []string{scConfig.PodCidr, scConfig.ServiceCidr} {
+		if entry != "" {
+			noProxy = append(noProxy, entry)
+		}
+	}
+	noProxy = append(noProxy, ".svc", "."+clusterDomain)
+	if scConfig.EnableLocalRegistry || scConfig.FromBundle != "" {
+		noProxy = append(noProxy, registry.ContainerName(scConfig.ClusterName))
+	}
+
+	return strings.Join(noProxy, ",")
+}
+
+// configureProxy makes the CLI, and the cluster nodes it creates, send requests through the
+// configured proxies.
+func configureProxy(scConfig *config.UnmanagedClusterConfig) error {
+	if !proxyEnabled(scConfig) {
+		return nil
+	}
+	return tkr.SetProxy(scConfig.HTTPProxy, scConfig.HTTPSProxy, noProxyList(scConfig))
+}
@@ -0,0 +1,36 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"testing"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+)
+
+func TestNoProxyList(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		ClusterName: "test",
+		PodCidr:     "10.244.0.0/16",
+		ServiceCidr: "10.96.0.0/16",
+		NoProxy:     "example.com, 192.168.0.0/16,",
+	}
+
+	expected := "example.com,192.168.0.0/16,localhost,127.0.0.1,10.244.0.0/16,10.96.0.0/16,.svc,.cluster.local"
+	if noProxy := noProxyList(scConfig); noProxy != expected {
+		t.Errorf("expected noProxy %q, was: %q", expected, noProxy)
+	}
+}
+
+func TestNoProxyListLocalRegistry(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		ClusterName:         "test",
+		EnableLocalRegistry: true,
+	}
+
+	expected := "localhost,127.0.0.1,.svc,.cluster.local,test-registry"
+	if noProxy := noProxyList(scConfig); noProxy != expected {
+		t.Errorf("expected noProxy %q, was: %q", expected, noProxy)
+	}
+}
@@ -8,23 +8,33 @@ package tanzu
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/bundle"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/cluster"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/events"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/kapp"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/kubeconfig"
 	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/packages"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/registry"
 	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
 )
 
@@ -33,9 +43,14 @@ const (
 	configFileName        = "config.yaml"
 	bootstrapLogName      = "bootstrap.log"
 	bomDir                = "bom"
+	failureArtifactsDir   = "failures"
 	tkgSysNamespace       = "tkg-system"
 	tkgSvcAcctName        = "core-pkgs"
 	tkgCoreRepoName       = "tkg-core-repository"
+	cniInstallName        = "cni"
+	kappControllerName    = "kapp-controller"
+	healthCheckTimeout    = 10 * time.Second
+	clusterReadyStatus    = "Ready"
 	tkgGlobalPkgNamespace = "tanzu-package-repo-global"
 	tceRepoName           = "community-repository"
 	tceRepoURL            = "projects.registry.vmware.com/tce/main:v0.11.0"
@@ -52,8 +67,15 @@ const cniNoneName = "none"
 
 // Cluster contains information about a cluster.
 type Cluster struct {
-	Name     string
-	Provider string
+	Name              string
+	Provider          string
+	Status            string
+	KubernetesVersion string
+	ControlPlaneNodes string
+	WorkerNodes       string
+	Cni               string
+	Created           string
+	Context           string
 }
 
 // UnmanagedCluster contains information about an unmanaged Tanzu cluster.
@@ -63,11 +85,40 @@ type UnmanagedCluster struct {
 	selectedCNIPkg       *CNIPackage
 	config               *config.UnmanagedClusterConfig
 	clusterDirectory     string
+	checkpoint           *checkpoint
+	events               events.Emitter
+	currentPhase         phase
+	// bundle is the manifest of the bundle the cluster is created from, if any
+	bundle *bundle.Manifest
+}
+
+// ClusterDescription contains the stored configuration of a cluster, along with its state as
+// reported by the cluster provider and the health of the components installed while bootstrapping.
+type ClusterDescription struct {
+	Name           string                         `json:"name" yaml:"name"`
+	Provider       string                         `json:"provider" yaml:"provider"`
+	Status         string                         `json:"status" yaml:"status"`
+	Endpoint       string                         `json:"endpoint" yaml:"endpoint"`
+	NodeImage      string                         `json:"nodeImage" yaml:"nodeImage"`
+	KubeconfigPath string                         `json:"kubeconfig" yaml:"kubeconfig"`
+	Registry       string                         `json:"registry,omitempty" yaml:"registry,omitempty"`
+	Nodes          []cluster.Node                 `json:"nodes" yaml:"nodes"`
+	Health         ClusterHealth                  `json:"health" yaml:"health"`
+	Config         *config.UnmanagedClusterConfig `json:"config" yaml:"config"`
+}
+
+// ClusterHealth contains the status of the components installed while bootstrapping a cluster.
+type ClusterHealth struct {
+	KappController  string `json:"kappController" yaml:"kappController"`
+	CorePackageRepo string `json:"corePackageRepo" yaml:"corePackageRepo"`
+	CNI             string `json:"cni" yaml:"cni"`
 }
 
 type CNIPackage struct {
 	fqPkgName  string
 	pkgVersion string
+	// valuesSchema is the OpenAPI v3 schema of the values accepted by the package, if provided
+	valuesSchema []byte
 }
 
 type Manager interface {
@@ -75,79 +126,440 @@ type Manager interface {
 	// cluster creation, kapp-controller installation, CNI installation, and more. The steps that are taken
 	// depend on the configuration passed into Deploy.
 	// If something goes wrong during deploy, an error and its corresponding exit code is returned.
-	Deploy(scConfig *config.UnmanagedClusterConfig) (int, error)
-	// List retrieves all known tanzu clusters are returns a list of them. If it's unable to interact with the
-	// underlying cluster provider, it returns an error.
+	// When the context is cancelled or the configured timeouts expire, Deploy stops waiting on the
+	// cluster and returns a timeout exit code.
+	Deploy(ctx context.Context, scConfig *config.UnmanagedClusterConfig) (int, error)
+	// Resume continues the creation of a cluster whose Deploy previously failed. Bootstrapping phases
+	// recorded as completed in the cluster directory are skipped. Like Deploy, an error and its
+	// corresponding exit code is returned when something goes wrong.
+	Resume(ctx context.Context, clusterName string) (int, error)
+	// List retrieves all known tanzu clusters are returns a list of them. Each cluster's status is retrieved
+	// from its cluster provider. If the provider cannot be communicated with, the status is reported as unknown.
 	List() ([]Cluster, error)
-	// Delete takes a cluster name and removes the cluster from the underlying cluster provider. If it is unable
-	// to communicate with the underlying cluster provider, it returns an error.
+	// Describe takes a cluster name and returns its stored configuration merged with its state from the
+	// underlying cluster provider and the health of the components installed in it. If the configuration
+	// cannot be read or the cluster provider cannot be communicated with, it returns an error.
+	Describe(name string) (*ClusterDescription, error)
+	// Delete takes a cluster name and removes the cluster from the underlying cluster provider. Its context is
+	// then removed from the default kubeconfig. If it is unable to communicate with the underlying cluster
+	// provider, it returns an error.
 	Delete(name string) error
+	// GetKubeconfig takes a cluster name and returns a standalone kubeconfig for the cluster, extracted from the
+	// default kubeconfig. If the cluster's context is not in the default kubeconfig, it returns an error.
+	GetKubeconfig(name string) ([]byte, error)
+	// Stop takes a cluster name and stops the cluster's nodes, keeping their state so the cluster can be
+	// started again. If the underlying cluster provider does not support stopping clusters, it returns an error.
+	Stop(name string) error
+	// Start takes a cluster name and starts the cluster's nodes. It then waits for the API server to respond,
+	// all nodes to be Ready and kapp-controller to be running. When the context is cancelled or expires, Start
+	// stops waiting and returns an error.
+	Start(ctx context.Context, name string) error
+	// Upgrade takes a cluster name and moves the cluster to the TKR at tkrLocation. kapp-controller, the core
+	// package repository and the CNI are updated in place. Node images are not changed, so a warning is
+	// logged when the new TKR requires recreating the cluster. When the context is cancelled or expires,
+	// Upgrade stops waiting and returns an error.
+	Upgrade(ctx context.Context, name, tkrLocation string) error
+	// ExportBundle writes everything required to create a cluster from a TKR to an archive, so clusters can be
+	// created from it without access to the registries hosting the TKR and its packages.
+	ExportBundle(opts *bundle.ExportOptions) error
 }
 
 // New returns a TanzuMgr for interacting with unmanaged clusters. It is implemented by TanzuUnmanaged.
-func New(parentLogger logger.Logger) Manager {
+// Options can be provided to further configure the Manager.
+// Ex: New(log, WithEventEmitter(emitter))
+func New(parentLogger logger.Logger, options ...Option) Manager {
 	log = parentLogger
-	return &UnmanagedCluster{}
+	t := &UnmanagedCluster{
+		events: events.NewNoopEmitter(),
+	}
+
+	for _, o := range options {
+		o.apply(t)
+	}
+
+	return t
 }
 
 // validateConfiguration makes sure the configuration is valid, returning an
 // error if there is an issue.
 func validateConfiguration(scConfig *config.UnmanagedClusterConfig) error {
-	if scConfig.TkrLocation == "" {
+	if scConfig.TkrLocation == "" && scConfig.FromBundle == "" {
 		return fmt.Errorf("Tanzu Kubernetes Release (TKR) not specified.") //nolint:revive,stylecheck
 	}
 	if scConfig.ClusterName == "" {
 		return fmt.Errorf("cluster name is required")
 	}
+	if scConfig.ContextName != "" && scConfig.ExistingClusterKubeconfig != "" {
+		return fmt.Errorf("%s cannot be set for an existing cluster, whose current context is used", config.ContextName)
+	}
 
 	if scConfig.Provider == "" {
 		// Should have been validated earlier, but not an error. We can just
 		// default it to kind.
 		scConfig.Provider = cluster.KindClusterManagerProvider
 	}
+	if err := cluster.ValidateProvider(scConfig.Provider); err != nil {
+		return err
+	}
+
+	if scConfig.Provider == cluster.KindClusterManagerProvider && scConfig.ContainerRuntime == "" {
+		scConfig.ContainerRuntime = cluster.DetectContainerRuntime()
+	}
+	if scConfig.ContainerRuntime != "" {
+		if err := cluster.ValidateContainerRuntime(scConfig.ContainerRuntime); err != nil {
+			return err
+		}
+		if scConfig.Provider != cluster.KindClusterManagerProvider && scConfig.ContainerRuntime != cluster.DockerRuntime {
+			return fmt.Errorf("%s %s is only supported by the %s provider", config.ContainerRuntime, scConfig.ContainerRuntime, cluster.KindClusterManagerProvider)
+		}
+	}
 
+	if len(scConfig.Nodes) != 0 {
+		err := validateNodes(scConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := validateKubernetesCustomization(scConfig)
+	if err != nil {
+		return err
+	}
+
+	err = validateIPFamily(scConfig)
+	if err != nil {
+		return err
+	}
+
+	// Workloads can only be scheduled to control planes when there are no workers
+	controlPlanes, workers := nodeCounts(scConfig)
+	if controlPlanes > 1 && workers == 0 {
+		scConfig.SchedulableControlPlanes = true
+	}
+
+	for i := range scConfig.CACertificates {
+		caPath, err := filepath.Abs(scConfig.CACertificates[i])
+		if err != nil {
+			return fmt.Errorf("invalid %s %q. Error: %s", config.CACertificates, scConfig.CACertificates[i], err.Error())
+		}
+		data, err := os.ReadFile(caPath)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate %s. Error: %s", caPath, err.Error())
+		}
+		if block, _ := pem.Decode(data); block == nil || block.Type != "CERTIFICATE" {
+			return fmt.Errorf("CA certificate %s is not a PEM encoded certificate", caPath)
+		}
+		scConfig.CACertificates[i] = caPath
+	}
+
+	if scConfig.EnableLocalRegistry {
+		if scConfig.ExistingClusterKubeconfig != "" {
+			return fmt.Errorf("a local registry cannot be used with %s", config.ExistingClusterKubeconfig)
+		}
+		if scConfig.Provider != cluster.KindClusterManagerProvider {
+			return fmt.Errorf("a local registry is only supported by the %s provider", cluster.KindClusterManagerProvider)
+		}
+	}
+
+	if scConfig.FromBundle != "" {
+		if scConfig.ExistingClusterKubeconfig != "" {
+			return fmt.Errorf("%s cannot be used with %s", config.FromBundle, config.ExistingClusterKubeconfig)
+		}
+		if scConfig.Provider != cluster.KindClusterManagerProvider {
+			return fmt.Errorf("%s is only supported by the %s provider", config.FromBundle, cluster.KindClusterManagerProvider)
+		}
+		bundlePath, err := filepath.Abs(scConfig.FromBundle)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q. Error: %s", config.FromBundle, scConfig.FromBundle, err.Error())
+		}
+		scConfig.FromBundle = bundlePath
+	}
+
+	timeouts := map[string]string{
+		config.Timeout:        scConfig.Timeout,
+		config.KappTimeout:    scConfig.KappTimeout,
+		config.RepoTimeout:    scConfig.RepoTimeout,
+		config.PackageTimeout: scConfig.PackageTimeout,
+	}
+	for name, value := range timeouts {
+		if _, err := parseTimeout(value); err != nil {
+			return fmt.Errorf("invalid %s %q, expected a duration such as 5m", name, value)
+		}
+	}
+
+	return validatePackages(scConfig.Packages)
+}
+
+// validateNodes validates the nodes customized in the configuration. The node counts are set from
+// them, so they are reported consistently, and the host paths of mounts are made absolute.
+func validateNodes(scConfig *config.UnmanagedClusterConfig) error {
+	if scConfig.Provider != cluster.KindClusterManagerProvider {
+		return fmt.Errorf("%s is only supported by the %s provider", config.Nodes, cluster.KindClusterManagerProvider)
+	}
+	err := cluster.ValidateNodes(scConfig.Nodes)
+	if err != nil {
+		return fmt.Errorf("invalid %s. Error: %s", config.Nodes, err.Error())
+	}
+
+	for i := range scConfig.Nodes {
+		for j := range scConfig.Nodes[i].ExtraMounts {
+			mount := &scConfig.Nodes[i].ExtraMounts[j]
+			hostPath, pathErr := filepath.Abs(mount.HostPath)
+			if pathErr != nil {
+				return fmt.Errorf("invalid mount host path %q. Error: %s", mount.HostPath, pathErr.Error())
+			}
+			mount.HostPath = hostPath
+		}
+	}
+	controlPlanes, workers := nodeCounts(scConfig)
+	scConfig.ControlPlaneNodeCount = strconv.Itoa(controlPlanes)
+	scConfig.WorkerNodeCount = strconv.Itoa(workers)
 	return nil
 }
 
+// nodeCounts returns the number of control plane and worker nodes of the cluster. When nodes are
+// configured individually they take precedence over the configured counts.
+func nodeCounts(scConfig *config.UnmanagedClusterConfig) (controlPlanes, workers int) {
+	if len(scConfig.Nodes) == 0 {
+		controlPlanes, _ = strconv.Atoi(scConfig.ControlPlaneNodeCount)
+		workers, _ = strconv.Atoi(scConfig.WorkerNodeCount)
+		return controlPlanes, workers
+	}
+	for i := range scConfig.Nodes {
+		if scConfig.Nodes[i].Role == config.NodeRoleWorker {
+			workers++
+		} else {
+			controlPlanes++
+		}
+	}
+	return controlPlanes, workers
+}
+
+// validateKubernetesCustomization makes sure the Kubernetes components are only customized with
+// providers that support it, and that the kubeadm config patches are valid.
+func validateKubernetesCustomization(scConfig *config.UnmanagedClusterConfig) error {
+	customizations := []struct {
+		name string
+		set  bool
+	}{
+		{config.FeatureGates, len(scConfig.FeatureGates) != 0},
+		{config.RuntimeConfig, len(scConfig.RuntimeConfig) != 0},
+		{config.APIServerExtraArgs, len(scConfig.APIServerExtraArgs) != 0},
+		{config.KubeletExtraArgs, len(scConfig.KubeletExtraArgs) != 0},
+		{config.KubeadmConfigPatches, len(scConfig.KubeadmConfigPatches) != 0},
+	}
+	for _, customization := range customizations {
+		if customization.set && scConfig.Provider != cluster.KindClusterManagerProvider {
+			return fmt.Errorf("%s is only supported by the %s provider", customization.name, cluster.KindClusterManagerProvider)
+		}
+	}
+
+	err := cluster.ValidateKubeadmConfigPatches(scConfig.KubeadmConfigPatches)
+	if err != nil {
+		return fmt.Errorf("invalid %s. Error: %s", config.KubeadmConfigPatches, err.Error())
+	}
+	return nil
+}
+
+// parseTimeout parses a timeout duration from configuration. An empty value represents no timeout.
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// withTimeout returns a child context that expires after the timeout. A timeout that is not
+// positive does not expire the context.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// runWithContext runs a blocking step that cannot be cancelled itself, returning the context's
+// error once it expires rather than waiting for the step. The step is left to finish in the
+// background, so only steps that are safe to abandon, such as downloads, should be run this way.
+func runWithContext(ctx context.Context, step func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- step()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// timeoutExitCode returns the exit code for a phase that timed out. When the parent context
+// has expired, the overall bootstrap timed out rather than the phase itself.
+func timeoutExitCode(parent context.Context, phaseCode int) int {
+	if parent.Err() != nil {
+		return ErrTimeout
+	}
+	return phaseCode
+}
+
 // Deploy deploys a new cluster.
-//nolint:funlen,gocyclo
-func (t *UnmanagedCluster) Deploy(scConfig *config.UnmanagedClusterConfig) (int, error) {
-	var err error
+func (t *UnmanagedCluster) Deploy(ctx context.Context, scConfig *config.UnmanagedClusterConfig) (exitCode int, err error) {
+	t.config = scConfig
+	t.emit(phaseBootstrap, events.StatusStarted, nil)
+	defer func() {
+		t.finishBootstrap(exitCode, err)
+	}()
 
 	// 1. Validate the configuration
 	if err := validateConfiguration(scConfig); err != nil {
 		return InvalidConfig, err
 	}
-	t.config = scConfig
+	// The address of a local registry is only known once it is started for this cluster
+	scConfig.LocalRegistry = ""
 
 	t.clusterDirectory, err = createClusterDirectory(t.config.ClusterName)
 	if err != nil {
 		return ErrCreatingClusterDirs, err
 	}
+	t.checkpoint = newCheckpoint(t.clusterDirectory)
 
 	// Configure the logger to capture all bootstrap activity
-	bootstrapLogsFp := filepath.Join(t.clusterDirectory, "bootstrap.log")
+	bootstrapLogsFp := filepath.Join(t.clusterDirectory, bootstrapLogName)
 	log.AddLogFile(bootstrapLogsFp)
 	log.Event(logger.FolderEmoji, "Created cluster directory")
 
+	exitCode, err = t.bootstrap(ctx)
+	if err != nil && t.config.RollbackOnFailure {
+		t.rollback()
+	}
+	return exitCode, err
+}
+
+// Resume continues the creation of a cluster that previously failed during Deploy. The
+// configuration is read from the cluster directory and every bootstrapping phase that was
+// recorded as completed is skipped.
+func (t *UnmanagedCluster) Resume(ctx context.Context, clusterName string) (exitCode int, err error) {
+	// Until the saved configuration is read, events are reported for the cluster by name
+	t.config = &config.UnmanagedClusterConfig{ClusterName: clusterName}
+	t.emit(phaseBootstrap, events.StatusStarted, nil)
+	defer func() {
+		t.finishBootstrap(exitCode, err)
+	}()
+
+	t.clusterDirectory, err = resolveClusterDir(clusterName)
+	if err != nil {
+		return ErrResumeCluster, err
+	}
+	configPath, err := resolveClusterConfig(clusterName)
+	if err != nil {
+		return ErrResumeCluster, err
+	}
+	scConfig, err := config.RenderFileToConfig(configPath)
+	if err != nil {
+		return ErrResumeCluster, err
+	}
+	t.config = scConfig
+	if err := validateConfiguration(t.config); err != nil {
+		return InvalidConfig, err
+	}
+	t.checkpoint, err = readCheckpoint(t.clusterDirectory)
+	if err != nil {
+		return ErrResumeCluster, err
+	}
+
+	// Configure the logger to capture all bootstrap activity
+	bootstrapLogsFp := filepath.Join(t.clusterDirectory, bootstrapLogName)
+	log.AddLogFile(bootstrapLogsFp)
+	log.Eventf(logger.FolderEmoji, "Resuming creation of cluster %s\n", clusterName)
+	for _, p := range t.checkpoint.CompletedPhases {
+		log.Style(outputIndent, color.Faint).Infof("Completed phase: %s\n", p)
+	}
+
+	exitCode, err = t.bootstrap(ctx)
+	if err != nil && t.config.RollbackOnFailure {
+		t.rollback()
+	}
+	return exitCode, err
+}
+
+// bootstrap runs all bootstrapping phases that are not yet recorded in the cluster's
+// checkpoint. Each phase is recorded upon completion so a failed bootstrap can be resumed.
+//nolint:funlen,gocyclo
+func (t *UnmanagedCluster) bootstrap(ctx context.Context) (int, error) {
+	var err error
+	scConfig := t.config
+
+	timeout, err := parseTimeout(scConfig.Timeout)
+	if err != nil {
+		return InvalidConfig, err
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	// Send all requests, including those of the cluster's nodes, through the configured proxies
+	err = configureProxy(scConfig)
+	if err != nil {
+		return InvalidConfig, fmt.Errorf("failed to configure proxy. Error: %s", err.Error())
+	}
+
 	// Log a warning if the user has given a ProviderConfiguration
 	if len(scConfig.ProviderConfiguration) != 0 {
 		log.Style(outputIndent, color.FgYellow).ReplaceLinef("Reading ProviderConfiguration from config file. All other provider specific configs may be ignored.")
 	}
 
+	// Import the bundle the cluster is created from, so nothing needs to be downloaded
+	if scConfig.FromBundle != "" {
+		if t.checkpoint.completed(phaseBundleImported) {
+			t.skipPhase(phaseBundleImported)
+			err = t.readImportedBundle()
+			if err != nil {
+				return ErrBundleImport, err
+			}
+		} else {
+			t.startPhase(phaseBundleImported)
+			log.Event(logger.PackageEmoji, "Importing bundle")
+			err = t.importBundle()
+			if err != nil {
+				return ErrBundleImport, fmt.Errorf("failed importing bundle. Error: %s", err.Error())
+			}
+			err = t.saveConfig()
+			if err != nil {
+				return ErrRenderingConfig, err
+			}
+			err = t.recordPhase(phaseBundleImported)
+			if err != nil {
+				return ErrBundleImport, err
+			}
+			t.finishPhase(phaseBundleImported, map[string]string{
+				"bundle":   scConfig.FromBundle,
+				"registry": scConfig.LocalRegistry,
+			})
+		}
+	}
+
 	// 2. Download and Read the TKR
+	if t.checkpoint.completed(phaseTkrResolved) {
+		t.skipPhase(phaseTkrResolved)
+	} else {
+		t.startPhase(phaseTkrResolved)
+	}
 	log.Event(logger.WrenchEmoji, "Resolving Tanzu Kubernetes Release (TKR)")
-	bomFileName, err := getTkrBom(scConfig.TkrLocation)
-	if err != nil {
-		return ErrTkrBom, fmt.Errorf("failed getting TKR BOM. Error: %s", err.Error())
+	bomFileName := buildFilesystemSafeBomName(scConfig.TkrLocation)
+	if !t.checkpoint.completed(phaseTkrResolved) {
+		bomFileName, err = getTkrBom(ctx, scConfig.TkrLocation)
+		if err != nil {
+			return timeoutExitCode(ctx, ErrTkrBom), fmt.Errorf("failed getting TKR BOM. Error: %s", err.Error())
+		}
 	}
 	configFp := filepath.Join(t.clusterDirectory, configFileName)
-	err = config.RenderConfigToFile(configFp, t.config)
+	err = t.saveConfig()
 	if err != nil {
 		return ErrRenderingConfig, err
 	}
 	log.Style(outputIndent, color.Faint).Infof("Rendered Config: %s\n", configFp)
-	log.Style(outputIndent, color.Faint).Infof("Bootstrap Logs: %s\n", bootstrapLogsFp)
+	log.Style(outputIndent, color.Faint).Infof("Bootstrap Logs: %s\n", filepath.Join(t.clusterDirectory, bootstrapLogName))
 
 	log.Event(logger.WrenchEmoji, "Processing Tanzu Kubernetes Release")
 	t.bom, err = parseTKRBom(bomFileName)
@@ -163,7 +575,7 @@ func (t *UnmanagedCluster) Deploy(scConfig *config.UnmanagedClusterConfig) (int,
 
 	// core package repository
 	log.Event(logger.PackageEmoji, "Selected core package repository")
-	log.Style(outputIndent, color.Faint).Infof("%s\n", t.bom.GetTKRCoreRepoBundlePath())
+	log.Style(outputIndent, color.Faint).Infof("%s\n", t.coreRepoBundlePath())
 	// core user package repositories
 	log.Event(logger.PackageEmoji, "Selected additional package repositories")
 	for _, additionalRepo := range scConfig.AdditionalPackageRepos {
@@ -176,98 +588,365 @@ func (t *UnmanagedCluster) Deploy(scConfig *config.UnmanagedClusterConfig) (int,
 	}
 	log.Event(logger.PackageEmoji, "Selected kapp-controller image bundle")
 	log.Style(outputIndent, color.Faint).Infof("%s\n", t.kappControllerBundle.GetRegistryURL())
+	if !t.checkpoint.completed(phaseTkrResolved) {
+		err = t.recordPhase(phaseTkrResolved)
+		if err != nil {
+			return ErrRenderingConfig, err
+		}
+		t.finishPhase(phaseTkrResolved, map[string]string{
+			"tkr":             scConfig.TkrLocation,
+			"nodeImage":       t.bom.GetTKRNodeImage(),
+			"coreRepo":        t.coreRepoBundlePath(),
+			"additionalRepos": strings.Join(scConfig.AdditionalPackageRepos, ","),
+			"kappBundle":      t.kappControllerBundle.GetRegistryURL(),
+		})
+	}
 
 	// 4. Create the cluster
-	var clusterToUse *cluster.KubernetesCluster
+	var kcBytes []byte
 
-	if scConfig.ExistingClusterKubeconfig != "" {
+	if t.checkpoint.completed(phaseClusterCreated) {
+		t.skipPhase(phaseClusterCreated)
+	} else {
+		t.startPhase(phaseClusterCreated)
+	}
+	// The provider names the context of a cluster it creates. Otherwise, it is read from the kubeconfig.
+	var providerContext string
+	switch {
+	case t.checkpoint.completed(phaseClusterCreated):
+		log.Eventf(logger.RocketEmoji, "Using previously created cluster %s\n", scConfig.ClusterName)
+		kcBytes, err = os.ReadFile(scConfig.KubeconfigPath)
+		if err != nil {
+			return ErrCreateCluster, fmt.Errorf("failed to read kubeconfig of previously created cluster, Error: %s", err.Error())
+		}
+	case scConfig.ExistingClusterKubeconfig != "":
 		log.Eventf(logger.RocketEmoji, "Using existing cluster\n")
-		clusterToUse, err = useExistingCluster(scConfig)
+		clusterToUse, err := useExistingCluster(scConfig)
 		if err != nil {
 			return ErrExistingCluster, fmt.Errorf("failed to use existing cluster, Error: %s", err.Error())
 		}
-	} else {
+		kcBytes = clusterToUse.Kubeconfig
+		err = t.recordClusterCreated()
+		if err != nil {
+			return ErrRenderingConfig, err
+		}
+	default:
 		log.Eventf(logger.RocketEmoji, "Creating cluster %s\n", scConfig.ClusterName)
-		clusterToUse, err = runClusterCreate(scConfig)
+		clusterToUse, err := runClusterCreate(ctx, scConfig)
+		if err != nil {
+			return timeoutExitCode(ctx, ErrCreateCluster), fmt.Errorf("failed to create cluster, Error: %s", err.Error())
+		}
+		kcBytes = clusterToUse.Kubeconfig
+		providerContext = clusterToUse.ContextName
+		err = t.recordClusterCreated()
+		if err != nil {
+			return ErrRenderingConfig, err
+		}
+		// Creation is not interrupted once started, so the timeout is checked after the cluster
+		// has been recorded for resume and rollback
+		if err := ctx.Err(); err != nil {
+			return timeoutExitCode(ctx, ErrCreateCluster), fmt.Errorf("failed to create cluster, Error: %s", err.Error())
+		}
+	}
+	err = resolveContextName(scConfig, providerContext)
+	if err != nil {
+		return ErrCreateCluster, err
+	}
+	err = t.saveConfig()
+	if err != nil {
+		return ErrRenderingConfig, err
+	}
+
+	// Remove the control plane taints before anything is installed, so it can be scheduled to them
+	if scConfig.SchedulableControlPlanes && scConfig.ExistingClusterKubeconfig == "" {
+		log.Event(logger.WrenchEmoji, "Making control plane nodes schedulable")
+		var clientSet kubernetes.Interface
+		clientSet, err = newClientSet(kcBytes)
+		if err != nil {
+			return ErrCreateCluster, fmt.Errorf("failed to load kubeconfig of cluster, Error: %s", err.Error())
+		}
+		err = untaintControlPlanes(ctx, clientSet)
 		if err != nil {
-			return ErrCreateCluster, fmt.Errorf("failed to create cluster, Error: %s", err.Error())
+			return ErrCreateCluster, err
 		}
 	}
 
-	kcBytes := clusterToUse.Kubeconfig
 	log.Style(outputIndent, color.Faint).Info("To troubleshoot, use:\n")
 	log.Style(outputIndent, color.Faint).Infof("kubectl ${COMMAND} --kubeconfig %s\n", scConfig.KubeconfigPath)
 
 	// 5. Install kapp-controller
-	kc, err := kapp.New(kcBytes)
-	if err != nil {
-		return ErrKappInstall, fmt.Errorf("failed to create kapp-controller manager, Error: %s", err.Error())
-	}
+	if t.checkpoint.completed(phaseKappInstalled) {
+		t.skipPhase(phaseKappInstalled)
+	} else {
+		t.startPhase(phaseKappInstalled)
+		kc, err := kapp.New(kcBytes)
+		if err != nil {
+			return ErrKappInstall, fmt.Errorf("failed to create kapp-controller manager, Error: %s", err.Error())
+		}
 
-	log.Event(logger.EnvelopeEmoji, "Installing kapp-controller")
-	kappDeployment, err := installKappController(t, kc)
-	if err != nil {
-		return ErrKappInstall, fmt.Errorf("failed to install kapp-controller, Error: %s", err.Error())
+		log.Event(logger.EnvelopeEmoji, "Installing kapp-controller")
+		kappDeployment, err := installKappController(t, kc)
+		if err != nil {
+			return ErrKappInstall, fmt.Errorf("failed to install kapp-controller, Error: %s", err.Error())
+		}
+
+		kappTimeout, err := parseTimeout(scConfig.KappTimeout)
+		if err != nil {
+			return InvalidConfig, err
+		}
+		kappCtx, kappCancel := withTimeout(ctx, kappTimeout)
+		err = blockForKappStatus(kappCtx, kappDeployment, kc)
+		kappCancel()
+		if err != nil {
+			return timeoutExitCode(ctx, ErrKappTimeout), err
+		}
+		err = t.recordPhase(phaseKappInstalled)
+		if err != nil {
+			return ErrKappInstall, err
+		}
+		t.finishPhase(phaseKappInstalled, map[string]string{
+			"kappBundle": t.kappControllerBundle.GetRegistryURL(),
+			"status":     "Running",
+		})
 	}
-	blockForKappStatus(kappDeployment, kc)
 
 	// 6. Install package repositories
 	pkgClient := packages.NewClient(kcBytes)
-	log.Event(logger.EnvelopeEmoji, "Installing package repositories")
-	createdCoreRepo, err := createPackageRepo(pkgClient, tkgSysNamespace, tkgCoreRepoName, t.bom.GetTKRCoreRepoBundlePath())
-	if err != nil {
-		return ErrCorePackageRepoInstall, fmt.Errorf("failed to install core package repo. Error: %s", err.Error())
-	}
+	if t.checkpoint.completed(phaseReposReconciled) {
+		t.skipPhase(phaseReposReconciled)
+	} else {
+		t.startPhase(phaseReposReconciled)
+		log.Event(logger.EnvelopeEmoji, "Installing package repositories")
+		createdCoreRepo, err := createPackageRepo(pkgClient, tkgSysNamespace, tkgCoreRepoName, t.coreRepoBundlePath())
+		if err != nil {
+			return ErrCorePackageRepoInstall, fmt.Errorf("failed to install core package repo. Error: %s", err.Error())
+		}
 
-	// Install the additional package repos
-	for _, additionalRepo := range scConfig.AdditionalPackageRepos {
-		kappFriendlyRepoName := strings.ReplaceAll(additionalRepo, "/", "-")
-		kappFriendlyRepoName = strings.ReplaceAll(kappFriendlyRepoName, ":", "-")
-		_, err = createPackageRepo(pkgClient, tkgGlobalPkgNamespace, kappFriendlyRepoName, additionalRepo)
+		// Install the additional package repos
+		for _, additionalRepo := range scConfig.AdditionalPackageRepos {
+			kappFriendlyRepoName := strings.ReplaceAll(additionalRepo, "/", "-")
+			kappFriendlyRepoName = strings.ReplaceAll(kappFriendlyRepoName, ":", "-")
+			_, err = createPackageRepo(pkgClient, tkgGlobalPkgNamespace, kappFriendlyRepoName, additionalRepo)
+			if err != nil {
+				return ErrOtherPackageRepoInstall, fmt.Errorf("failed to install adiditonal package repo. Error: %s", err.Error())
+			}
+		}
+		repoTimeout, err := parseTimeout(scConfig.RepoTimeout)
 		if err != nil {
-			return ErrOtherPackageRepoInstall, fmt.Errorf("failed to install adiditonal package repo. Error: %s", err.Error())
+			return InvalidConfig, err
+		}
+		repoCtx, repoCancel := withTimeout(ctx, repoTimeout)
+		err = blockForRepoStatus(repoCtx, createdCoreRepo, pkgClient)
+		timedOut := repoCtx.Err() != nil
+		repoCancel()
+		if err != nil && timedOut {
+			return timeoutExitCode(ctx, ErrRepoTimeout), err
+		}
+		if err != nil {
+			log.Errorf("failed to check package repository status: %s\n", err.Error())
+			t.failPhase(phaseReposReconciled, 0, err)
+		} else {
+			err = t.recordPhase(phaseReposReconciled)
+			if err != nil {
+				return ErrCorePackageRepoInstall, err
+			}
+			t.finishPhase(phaseReposReconciled, map[string]string{
+				"coreRepo": t.coreRepoBundlePath(),
+				"status":   "Reconcile succeeded",
+			})
 		}
 	}
-	blockForRepoStatus(createdCoreRepo, pkgClient)
 
 	// 7. Install CNI
 	// CNI plugins are installed as best effort. If no plugin is resolved in the
 	// repository, no CNI is installed, yet the cluster will still run.
-	log.Event(logger.GlobeEmoji, "Installing CNI")
-	t.selectedCNIPkg, err = resolveCNI(pkgClient, t.config.Cni)
-
-	// No CNI package was resolved to install
-	if err != nil {
-		log.Style(outputIndent, color.FgYellow).Warnf("No CNI installed: %s.\n", err)
+	if t.checkpoint.completed(phaseCniInstalled) {
+		t.skipPhase(phaseCniInstalled)
 	} else {
-		// CNI package resolved, do install
-		log.Style(outputIndent, color.Faint).Infof("%s:%s\n", t.selectedCNIPkg.fqPkgName, t.selectedCNIPkg.pkgVersion)
-		err = installCNI(pkgClient, t)
+		t.startPhase(phaseCniInstalled)
+		log.Event(logger.GlobeEmoji, "Installing CNI")
+		cniDetails := map[string]string{"cni": t.config.Cni}
+		t.selectedCNIPkg, err = resolveCNI(pkgClient, t.config.Cni)
+
+		// No CNI package was resolved to install
+		if err != nil {
+			log.Style(outputIndent, color.FgYellow).Warnf("No CNI installed: %s.\n", err)
+			cniDetails["status"] = fmt.Sprintf("No CNI installed: %s", err)
+		} else {
+			// CNI package resolved, do install
+			log.Style(outputIndent, color.Faint).Infof("%s:%s\n", t.selectedCNIPkg.fqPkgName, t.selectedCNIPkg.pkgVersion)
+			err = installCNI(pkgClient, t)
+			if err != nil {
+				return ErrCniInstall, fmt.Errorf("failed to install the CNI package. Error: %s", err.Error())
+			}
+			cniDetails["package"] = fmt.Sprintf("%s:%s", t.selectedCNIPkg.fqPkgName, t.selectedCNIPkg.pkgVersion)
+			cniDetails["status"] = "Installed"
+
+			// calico-node must run on every node before pods can be networked
+			if isCalico(t.config) {
+				var cniExitCode int
+				cniExitCode, err = waitForCalico(ctx, kcBytes, scConfig)
+				if err != nil {
+					return cniExitCode, err
+				}
+				cniDetails["status"] = "Ready"
+			}
+		}
+		err = t.recordPhase(phaseCniInstalled)
 		if err != nil {
-			return ErrCniInstall, fmt.Errorf("failed to install the CNI package. Error: %s", err.Error())
+			return ErrCniInstall, err
+		}
+		t.finishPhase(phaseCniInstalled, cniDetails)
+	}
+
+	// 8. Install packages from the configuration
+	if len(scConfig.Packages) != 0 {
+		if t.checkpoint.completed(phasePackagesInstalled) {
+			t.skipPhase(phasePackagesInstalled)
+		} else {
+			t.startPhase(phasePackagesInstalled)
+			log.Event(logger.PackageEmoji, "Installing packages")
+			var pkgDetails map[string]string
+			var pkgExitCode int
+			pkgDetails, pkgExitCode, err = installPackages(ctx, pkgClient, t)
+			if err != nil {
+				return pkgExitCode, err
+			}
+			err = t.recordPhase(phasePackagesInstalled)
+			if err != nil {
+				return ErrPackageInstall, err
+			}
+			t.finishPhase(phasePackagesInstalled, pkgDetails)
 		}
 	}
 
-	// 8. Update kubeconfig and context
+	// 9. Update kubeconfig and context
+	t.startPhase(phaseKubeconfigMerged)
 	kubeConfigMgr := kubeconfig.NewManager()
-	err = mergeKubeconfigAndSetContext(kubeConfigMgr, scConfig.KubeconfigPath, scConfig.ClusterName)
+	err = mergeKubeconfigAndSetContext(kubeConfigMgr, scConfig)
 	if err != nil {
 		log.Warnf("Failed to merge kubeconfig and set your context. Cluster should still work! Error: %s", err)
+		t.failPhase(phaseKubeconfigMerged, 0, err)
+	} else {
+		// Recorded so a rollback only removes a context this bootstrap merged
+		err = t.recordPhase(phaseKubeconfigMerged)
+		if err != nil {
+			log.Warnf("Failed to record the merged kubeconfig context. Error: %s", err)
+		}
+		t.finishPhase(phaseKubeconfigMerged, map[string]string{
+			"context": scConfig.ContextName,
+		})
 	}
 
-	// 8. Return
+	// 10. Return
 	log.Event(logger.GreenCheckEmoji, "Cluster created")
-	log.Eventf(logger.ControllerEmoji, "kubectl context set to %s\n\n", scConfig.ClusterName)
+	if scConfig.SkipContextSwitch {
+		log.Eventf(logger.ControllerEmoji, "kubectl context %s added, current context unchanged\n\n", scConfig.ContextName)
+	} else {
+		log.Eventf(logger.ControllerEmoji, "kubectl context set to %s\n\n", scConfig.ContextName)
+	}
 	// provide user example commands to run
 	log.Infof("View available packages:\n")
 	log.Style(outputIndent, color.FgGreen).Infof("tanzu package available list\n")
 	log.Infof("View running pods:\n")
 	log.Style(outputIndent, color.FgGreen).Infof("kubectl get po -A\n")
+	if scConfig.LocalRegistry != "" {
+		log.Infof("Push images to the local registry:\n")
+		log.Style(outputIndent, color.FgGreen).Infof("docker push %s/${IMAGE}\n", scConfig.LocalRegistry)
+	}
 	log.Infof("Delete this cluster:\n")
 	log.Style(outputIndent, color.FgGreen).Infof("tanzu unmanaged delete %s\n", scConfig.ClusterName)
 	return Success, nil
 }
 
+// rollback removes what a failed bootstrap left behind: the cluster, its merged kubeconfig context
+// and the cluster directory. The bootstrap log and config are first copied to a failure artifacts
+// directory so the failure can still be inspected. Problems during rollback are logged as warnings
+// so that the original bootstrap error remains the one reported.
+func (t *UnmanagedCluster) rollback() {
+	log.Eventf(logger.TestTubeEmoji, "Rolling back cluster %s\n", t.config.ClusterName)
+	// The provider may remove the context from the cluster's kubeconfig when deleting it
+	contextName := getKubeContextName(t.config)
+
+	// Clusters that were not created by this bootstrap are never deleted
+	if t.config.ExistingClusterKubeconfig == "" {
+		cm := cluster.NewClusterManager(t.config)
+		err := cm.Delete(t.config)
+		if err != nil {
+			log.Style(outputIndent, color.FgYellow).Warnf("Failed to delete cluster: %s\n", err.Error())
+		}
+	}
+
+	if t.config.LocalRegistry != "" {
+		err := registry.Delete(cluster.NewContainerRuntime(t.config.ContainerRuntime), t.config.ClusterName)
+		if err != nil {
+			log.Style(outputIndent, color.FgYellow).Warnf("Failed to delete registry: %s\n", err.Error())
+		}
+	}
+
+	// Only a context merged by this bootstrap is removed
+	if t.checkpoint.completed(phaseKubeconfigMerged) {
+		err := removeKubeContext(kubeconfig.NewManager(), t.config, contextName)
+		if err != nil {
+			log.Style(outputIndent, color.FgYellow).Warnf("Failed to remove kubeconfig context: %s\n", err.Error())
+		}
+	}
+
+	artifactsDir, err := saveFailureArtifacts(t.clusterDirectory, t.config.ClusterName)
+	if err != nil {
+		log.Style(outputIndent, color.FgYellow).Warnf("Failed to save failure artifacts: %s\n", err.Error())
+	} else {
+		log.Style(outputIndent, color.Faint).Infof("Failure artifacts: %s\n", artifactsDir)
+	}
+
+	err = os.RemoveAll(t.clusterDirectory)
+	if err != nil {
+		log.Style(outputIndent, color.FgYellow).Warnf("Failed to remove cluster directory %s. Be sure to manually delete.\n", t.clusterDirectory)
+	}
+}
+
+// recordPhase marks a bootstrapping phase as completed in the cluster's checkpoint.
+func (t *UnmanagedCluster) recordPhase(p phase) error {
+	err := t.checkpoint.record(p)
+	if err != nil {
+		return fmt.Errorf("failed to record completion of phase %s. Error: %s", p, err.Error())
+	}
+	return nil
+}
+
+// recordClusterCreated persists the configuration, as the kubeconfig path and node image are only
+// known once the cluster exists, and records and reports the cluster as created. It is recorded as
+// soon as the provider returns the cluster, so resuming after any later failure uses it rather than
+// creating it again.
+func (t *UnmanagedCluster) recordClusterCreated() error {
+	err := t.saveConfig()
+	if err != nil {
+		return err
+	}
+	err = t.recordPhase(phaseClusterCreated)
+	if err != nil {
+		return err
+	}
+	t.finishPhase(phaseClusterCreated, map[string]string{
+		"provider":   t.config.Provider,
+		"nodeImage":  t.config.NodeImage,
+		"kubeconfig": t.config.KubeconfigPath,
+	})
+	return nil
+}
+
+// saveConfig persists the current configuration to the cluster directory, replacing any
+// previously rendered configuration.
+func (t *UnmanagedCluster) saveConfig() error {
+	configFp := filepath.Join(t.clusterDirectory, configFileName)
+	err := os.Remove(configFp)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace config file at %s. Error: %s", configFp, err.Error())
+	}
+
+	return config.RenderConfigToFile(configFp, t.config)
+}
+
 // List lists the unmanaged clusters.
 func (t *UnmanagedCluster) List() ([]Cluster, error) {
 	var clusters []Cluster
@@ -308,15 +987,126 @@ func (t *UnmanagedCluster) List() ([]Cluster, error) {
 			return nil, err
 		}
 
-		clusters = append(clusters, Cluster{
-			Name:     scc.ClusterName,
-			Provider: scc.Provider,
-		})
+		clusters = append(clusters, describeListedCluster(scc))
 	}
 
 	return clusters, nil
 }
 
+// describeListedCluster combines the stored configuration of a cluster with its state from the cluster
+// provider. When the provider does not know of any nodes, the configured node counts are reported.
+func describeListedCluster(scc *config.UnmanagedClusterConfig) Cluster {
+	c := Cluster{
+		Name:              scc.ClusterName,
+		Provider:          scc.Provider,
+		Status:            cluster.StatusUnknown,
+		ControlPlaneNodes: scc.ControlPlaneNodeCount,
+		WorkerNodes:       scc.WorkerNodeCount,
+		Cni:               scc.Cni,
+		Context:           getKubeContextName(scc),
+	}
+
+	bom, err := parseTKRBom(buildFilesystemSafeBomName(scc.TkrLocation))
+	if err == nil {
+		c.KubernetesVersion = bom.GetTKRKubernetesVersion()
+	}
+
+	cm := cluster.NewClusterManager(scc)
+	kc, err := cm.Get(scc)
+	if err != nil {
+		return c
+	}
+	c.Status = kc.Status
+	if len(kc.Nodes) > 0 {
+		controlPlanes, workers := kc.NodeCounts()
+		c.ControlPlaneNodes = strconv.Itoa(controlPlanes)
+		c.WorkerNodes = strconv.Itoa(workers)
+	}
+	if !kc.CreatedAt.IsZero() {
+		c.Created = kc.CreatedAt.Format(time.RFC3339)
+	}
+
+	return c
+}
+
+// Describe describes an unmanaged cluster.
+func (t *UnmanagedCluster) Describe(name string) (*ClusterDescription, error) {
+	configPath, err := resolveClusterConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	scConfig, err := config.RenderFileToConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := cluster.NewClusterManager(scConfig)
+	kc, err := cm.Get(scConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeImage := kc.NodeImage
+	if nodeImage == "" {
+		nodeImage = scConfig.NodeImage
+	}
+	description := &ClusterDescription{
+		Name:           scConfig.ClusterName,
+		Provider:       scConfig.Provider,
+		Status:         kc.Status,
+		Endpoint:       kc.Endpoint,
+		NodeImage:      nodeImage,
+		KubeconfigPath: scConfig.KubeconfigPath,
+		Registry:       scConfig.LocalRegistry,
+		Nodes:          kc.Nodes,
+		Health:         getClusterHealth(kc),
+		Config:         scConfig,
+	}
+
+	return description, nil
+}
+
+// getClusterHealth checks the status of kapp-controller, the core package repository and the CNI
+// package install. The cluster must be running for its health to be checked.
+func getClusterHealth(kc *cluster.KubernetesCluster) ClusterHealth {
+	unavailable := fmt.Sprintf("Unavailable, cluster is %s", kc.Status)
+	health := ClusterHealth{
+		KappController:  unavailable,
+		CorePackageRepo: unavailable,
+		CNI:             unavailable,
+	}
+	if kc.Status != cluster.StatusRunning || len(kc.Kubeconfig) == 0 {
+		return health
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	kappClient, err := kapp.New(kc.Kubeconfig)
+	if err != nil {
+		health.KappController = err.Error()
+	} else {
+		health.KappController = kappClient.Status(ctx, tkgSysNamespace, kappControllerName)
+	}
+
+	pkgClient := packages.NewClient(kc.Kubeconfig)
+	health.CorePackageRepo = packageStatus(pkgClient.GetRepositoryStatus(ctx, tkgSysNamespace, tkgCoreRepoName))
+	health.CNI = packageStatus(pkgClient.GetPackageInstallStatus(ctx, tkgSysNamespace, cniInstallName))
+
+	return health
+}
+
+// packageStatus converts the result of a package status lookup into the status to report.
+func packageStatus(status string, err error) string {
+	if apierrors.IsNotFound(err) {
+		return "Not installed"
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return status
+}
+
 // Delete deletes an unmanaged cluster.
 func (t *UnmanagedCluster) Delete(name string) error {
 	var err error
@@ -333,6 +1123,8 @@ func (t *UnmanagedCluster) Delete(name string) error {
 		return err
 	}
 
+	// The provider may remove the context from the cluster's kubeconfig when deleting it
+	contextName := getKubeContextName(t.config)
 	cm := cluster.NewClusterManager(t.config)
 
 	err = cm.Delete(t.config)
@@ -340,6 +1132,18 @@ func (t *UnmanagedCluster) Delete(name string) error {
 		return err
 	}
 
+	if t.config.LocalRegistry != "" {
+		err = registry.Delete(cluster.NewContainerRuntime(t.config.ContainerRuntime), t.config.ClusterName)
+		if err != nil {
+			log.Warnf("Cluster deleted but failed to remove its registry. Error: %s", err)
+		}
+	}
+
+	err = removeKubeContext(kubeconfig.NewManager(), t.config, contextName)
+	if err != nil {
+		log.Warnf("Cluster deleted but failed to remove its kubeconfig context %s. Error: %s", contextName, err)
+	}
+
 	err = os.RemoveAll(t.clusterDirectory)
 	if err != nil {
 		log.Warnf("Cluster deleted but failed to remove config %s. Be sure to manually delete.", t.clusterDirectory)
@@ -348,6 +1152,89 @@ func (t *UnmanagedCluster) Delete(name string) error {
 	return nil
 }
 
+// GetKubeconfig returns a standalone kubeconfig for an unmanaged cluster.
+func (t *UnmanagedCluster) GetKubeconfig(name string) ([]byte, error) {
+	configPath, err := resolveClusterConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	t.config, err = config.RenderFileToConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName := getKubeContextName(t.config)
+	if contextName == "" {
+		return nil, fmt.Errorf("unable to determine the kubeconfig context of cluster %s", name)
+	}
+	data, err := kubeconfig.NewManager().Export(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export kubeconfig context %s. Error: %s", contextName, err.Error())
+	}
+	return data, nil
+}
+
+// Stop stops an unmanaged cluster.
+func (t *UnmanagedCluster) Stop(name string) error {
+	configPath, err := resolveClusterConfig(name)
+	if err != nil {
+		return err
+	}
+	t.config, err = config.RenderFileToConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	cm := cluster.NewClusterManager(t.config)
+	return cm.Stop(t.config)
+}
+
+// Start starts a stopped unmanaged cluster and waits for it to be usable.
+func (t *UnmanagedCluster) Start(ctx context.Context, name string) error {
+	configPath, err := resolveClusterConfig(name)
+	if err != nil {
+		return err
+	}
+	t.config, err = config.RenderFileToConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	cm := cluster.NewClusterManager(t.config)
+	err = cm.Start(t.config)
+	if err != nil {
+		return err
+	}
+
+	kcBytes, err := os.ReadFile(t.config.KubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig of cluster %s. Error: %s", name, err.Error())
+	}
+	clientSet, err := newClientSet(kcBytes)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig of cluster %s. Error: %s", name, err.Error())
+	}
+
+	log.Event(logger.RocketEmoji, "Waiting for cluster to be ready")
+	err = blockForClusterReady(ctx, clientSet)
+	if err != nil {
+		return err
+	}
+
+	log.Event(logger.EnvelopeEmoji, "Waiting for kapp-controller")
+	kc, err := kapp.New(kcBytes)
+	if err != nil {
+		return fmt.Errorf("failed to create kapp-controller manager, Error: %s", err.Error())
+	}
+	kappDeployment := &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kappControllerName,
+			Namespace: tkgSysNamespace,
+		},
+	}
+	return blockForKappStatus(ctx, kappDeployment, kc)
+}
+
 func getUnmanagedBomPath() (path string, err error) {
 	tkgUnmanagedConfigDir, err := config.GetUnmanagedConfigPath()
 	if err != nil {
@@ -357,6 +1244,47 @@ func getUnmanagedBomPath() (path string, err error) {
 	return filepath.Join(tkgUnmanagedConfigDir, bomDir), nil
 }
 
+func getFailureArtifactsPath() (path string, err error) {
+	tkgUnmanagedConfigDir, err := config.GetUnmanagedConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(tkgUnmanagedConfigDir, failureArtifactsDir), nil
+}
+
+// saveFailureArtifacts copies the bootstrap log and config of a cluster into a new, timestamped,
+// directory under the failure artifacts path. It returns the directory the artifacts were saved to.
+func saveFailureArtifacts(clusterDir, clusterName string) (string, error) {
+	failuresPath, err := getFailureArtifactsPath()
+	if err != nil {
+		return "", err
+	}
+
+	artifactsDir := filepath.Join(failuresPath, fmt.Sprintf("%s-%s", clusterName, time.Now().Format("20060102150405")))
+	err = os.MkdirAll(artifactsDir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	for _, fileName := range []string{bootstrapLogName, configFileName} {
+		data, err := os.ReadFile(filepath.Join(clusterDir, fileName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		err = os.WriteFile(filepath.Join(artifactsDir, fileName), data, 0644)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return artifactsDir, nil
+}
+
 func buildFilesystemSafeBomName(bomFileName string) (path string) {
 	var sb strings.Builder
 	for _, char := range bomFileName {
@@ -440,7 +1368,7 @@ func createClusterDirectory(clusterName string) (string, error) {
 
 	// if it does not exist, which is expected, create it
 	if !os.IsNotExist(err) {
-		return "", fmt.Errorf("directory %s already exists, this cluster must be deleted before proceeding", fp)
+		return "", fmt.Errorf("directory %s already exists, this cluster must be deleted or resumed before proceeding", fp)
 	}
 
 	err = os.MkdirAll(fp, 0755)
@@ -451,7 +1379,7 @@ func createClusterDirectory(clusterName string) (string, error) {
 	return fp, nil
 }
 
-func getTkrBom(registry string) (string, error) {
+func getTkrBom(ctx context.Context, registry string) (string, error) {
 	log.Style(outputIndent, color.Faint).Infof("%s\n", registry)
 	expectedBomName := buildFilesystemSafeBomName(registry)
 
@@ -486,7 +1414,7 @@ func getTkrBom(registry string) (string, error) {
 		return "", fmt.Errorf("failed to create new TkrImageReader: %s", err)
 	}
 
-	err = blockForBomImage(bomImage, bomPath, expectedBomName)
+	err = blockForBomImage(ctx, bomImage, bomPath, expectedBomName)
 	if err != nil {
 		return "", fmt.Errorf("failed to download tkr image: %s", err)
 	}
@@ -522,21 +1450,22 @@ func getTkrBom(registry string) (string, error) {
 	return expectedBomName, nil
 }
 
-func blockForBomImage(b tkr.ImageReader, bomPath, expectedBomName string) error {
+func blockForBomImage(ctx context.Context, b tkr.ImageReader, bomPath, expectedBomName string) error {
 	f := filepath.Join(bomPath, expectedBomName)
 
 	// start a go routine to animate the downloading logs while the imgpkg libraries get the bom image
-	ctx, cancel := context.WithCancel(context.Background())
+	animateCtx, cancel := context.WithCancel(ctx)
 	go func(ctx context.Context) {
 		log.Style(outputIndent, color.Reset).AnimateProgressWithOptions(
 			logger.AnimatorWithContext(ctx),
 			logger.AnimatorWithMaxLen(maxProgressLength),
 			logger.AnimatorWithMessagef("Downloading to: %s", f),
 		)
-	}(ctx)
+	}(animateCtx)
 
-	// This will block and the go routine will continue to animate the logging
-	err := b.DownloadImage()
+	// This will block, until the download completes or the context expires, and the go routine
+	// will continue to animate the logging
+	err := runWithContext(ctx, b.DownloadImage)
 	if err != nil {
 		cancel()
 		return err
@@ -566,6 +1495,10 @@ func parseTKRBom(fileName string) (*tkr.Bom, error) {
 
 func resolveKappBundle(t *UnmanagedCluster) error {
 	var err error
+	if t.bundle != nil {
+		t.kappControllerBundle, err = t.bundledKappImage()
+		return err
+	}
 	t.kappControllerBundle, err = t.bom.GetTKRKappImage()
 	if err != nil {
 		return err
@@ -573,7 +1506,10 @@ func resolveKappBundle(t *UnmanagedCluster) error {
 	return nil
 }
 
-func runClusterCreate(scConfig *config.UnmanagedClusterConfig) (*cluster.KubernetesCluster, error) {
+// runClusterCreate creates the cluster with its provider. The base image is pulled until the context
+// expires. Providers cannot interrupt cluster creation, so once started it is completed and the caller
+// checks the context afterwards, leaving a cluster that resume or rollback can pick up.
+func runClusterCreate(ctx context.Context, scConfig *config.UnmanagedClusterConfig) (*cluster.KubernetesCluster, error) {
 	clusterDir, err := resolveClusterDir(scConfig.ClusterName)
 	if err != nil {
 		return nil, err
@@ -597,12 +1533,24 @@ func runClusterCreate(scConfig *config.UnmanagedClusterConfig) (*cluster.Kuberne
 		}
 	}
 
-	err = blockForPullingBaseImage(clusterManager, scConfig)
+	// Registries started from a bundle already run by the time the cluster is created
+	if scConfig.EnableLocalRegistry && scConfig.LocalRegistry == "" {
+		scConfig.LocalRegistry, err = registry.Start(cluster.NewContainerRuntime(scConfig.ContainerRuntime), scConfig.ClusterName, registry.DefaultImage)
+		if err != nil {
+			return nil, err
+		}
+		log.Style(outputIndent, color.Faint).Infof("Started registry at %s\n", scConfig.LocalRegistry)
+	}
+
+	err = blockForPullingBaseImage(ctx, clusterManager, scConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	kc, err := blockForClusterCreate(clusterManager, scConfig)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	kc, err := blockForClusterCreate(ctx, clusterManager, scConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -624,19 +1572,21 @@ func useExistingCluster(scConfig *config.UnmanagedClusterConfig) (*cluster.Kuber
 	return kc, nil
 }
 
-func blockForPullingBaseImage(cm cluster.Manager, scConfig *config.UnmanagedClusterConfig) error {
+func blockForPullingBaseImage(ctx context.Context, cm cluster.Manager, scConfig *config.UnmanagedClusterConfig) error {
 	// start a go routine to animate the downloading logs while the docker exec gets the image
-	ctx, cancel := context.WithCancel(context.Background())
+	animateCtx, cancel := context.WithCancel(ctx)
 	go func(ctx context.Context) {
 		log.Style(outputIndent, color.Reset).AnimateProgressWithOptions(
 			logger.AnimatorWithContext(ctx),
 			logger.AnimatorWithMaxLen(maxProgressLength),
 			logger.AnimatorWithMessagef("Pulling base image"),
 		)
-	}(ctx)
+	}(animateCtx)
 
-	// This should block
-	err := cm.Prepare(scConfig)
+	// This should block, until the image is pulled or the context expires
+	err := runWithContext(ctx, func() error {
+		return cm.Prepare(scConfig)
+	})
 	if err != nil {
 		cancel()
 		return err
@@ -649,16 +1599,16 @@ func blockForPullingBaseImage(cm cluster.Manager, scConfig *config.UnmanagedClus
 	return nil
 }
 
-func blockForClusterCreate(cm cluster.Manager, scConfig *config.UnmanagedClusterConfig) (*cluster.KubernetesCluster, error) {
+func blockForClusterCreate(ctx context.Context, cm cluster.Manager, scConfig *config.UnmanagedClusterConfig) (*cluster.KubernetesCluster, error) {
 	// start a go routine to animate the downloading logs while the docker exec gets the image
-	ctx, cancel := context.WithCancel(context.Background())
+	animateCtx, cancel := context.WithCancel(ctx)
 	go func(ctx context.Context) {
 		log.Style(outputIndent, color.Reset).AnimateProgressWithOptions(
 			logger.AnimatorWithContext(ctx),
 			logger.AnimatorWithMaxLen(maxProgressLength),
 			logger.AnimatorWithMessagef("Creating cluster"),
 		)
-	}(ctx)
+	}(animateCtx)
 
 	// This should block
 	kc, err := cm.Create(scConfig)
@@ -684,6 +1634,33 @@ func installKappController(t *UnmanagedCluster, kc kapp.Manager) (*v1.Deployment
 	if err != nil {
 		return nil, err
 	}
+
+	configValues := &kapp.ConfigValues{
+		DangerousSkipTLSVerify: append([]string{}, t.config.InsecureRegistries...),
+	}
+	configValues.CACerts, err = readCACertificates(t.config.CACertificates)
+	if err != nil {
+		return nil, err
+	}
+	if proxyEnabled(t.config) {
+		configValues.HTTPProxy = t.config.HTTPProxy
+		configValues.HTTPSProxy = t.config.HTTPSProxy
+		configValues.NoProxy = noProxyList(t.config)
+	}
+	// The local registry is served over plain HTTP
+	if t.config.LocalRegistry != "" {
+		configValues.DangerousSkipTLSVerify = append(configValues.DangerousSkipTLSVerify, registry.ClusterAddress(t.config.ClusterName))
+	}
+	valuesBytes, err := configValues.Render()
+	if err != nil {
+		return nil, err
+	}
+	if valuesBytes != nil {
+		err = t.kappControllerBundle.AddYttYamlValuesBytes(valuesBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
 	t.kappControllerBundle.SetRelativeConfigPath("./config")
 	kappBytes, err := t.kappControllerBundle.RenderYaml()
 	if err != nil {
@@ -698,9 +1675,26 @@ func installKappController(t *UnmanagedCluster, kc kapp.Manager) (*v1.Deployment
 	return kappControllerCreated, nil
 }
 
-func blockForKappStatus(kappDeployment *v1.Deployment, kc kapp.Manager) {
-	// Create the parent context and fire a go routine to animate the logging progress
-	ctx, cancel := context.WithCancel(context.Background())
+// readCACertificates concatenates the PEM encoded certificate authorities at the paths.
+func readCACertificates(caPaths []string) (string, error) {
+	var caCerts strings.Builder
+	for _, caPath := range caPaths {
+		data, err := os.ReadFile(caPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read CA certificate %s. Error: %s", caPath, err.Error())
+		}
+		caCerts.Write(data)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			caCerts.WriteString("\n")
+		}
+	}
+	return caCerts.String(), nil
+}
+
+func blockForKappStatus(ctx context.Context, kappDeployment *v1.Deployment, kc kapp.Manager) error {
+	// Create the animation context and fire a go routine to animate the logging progress
+	animateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	status := make(chan string, 1)
 	go func(ctx context.Context) {
 		log.Style(outputIndent, color.Faint).AnimateProgressWithOptions(
@@ -709,33 +1703,162 @@ func blockForKappStatus(kappDeployment *v1.Deployment, kc kapp.Manager) {
 			logger.AnimatorWithMessagef("kapp-controller status: %s"),
 			logger.AnimatorWithStatusChan(status),
 		)
-	}(ctx)
+	}(animateCtx)
 
-	// Wait for kapp-controller to be running; report status into the status channel
-	// TODO: jpmcb - we need to handle timeouts, errors, etc for kapp controller not running
+	// Wait for kapp-controller to be running, or the context to expire; report status into the status channel
+	var lastState string
 	for {
-		kappState := kc.Status(kappDeployment.Namespace, kappDeployment.Name)
+		kappState := kc.Status(ctx, kappDeployment.Namespace, kappDeployment.Name)
+		if ctx.Err() != nil {
+			cancel()
+			log.Style(outputIndent, color.FgYellow).ReplaceLinef("kapp-controller status: %s", lastState)
+			return fmt.Errorf("timed out waiting for kapp-controller to be running. Last observed status: %s", lastState)
+		}
 		status <- kappState
 		if kappState == "Running" {
 			cancel()
 			log.Style(outputIndent, color.Faint).ReplaceLinef("kapp-controller status: %s", kappState)
-			break
+			return nil
+		}
+		lastState = kappState
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+// newClientSet returns a clientset for the cluster the kubeconfig points to.
+func newClientSet(kcBytes []byte) (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kcBytes)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// blockForClusterReady waits for the cluster's API server to respond and all of its nodes to be Ready.
+func blockForClusterReady(ctx context.Context, clientSet kubernetes.Interface) error {
+	// Create the animation context and fire a go routine to animate the logging progress
+	animateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	status := make(chan string, 1)
+	go func(ctx context.Context) {
+		log.Style(outputIndent, color.Faint).AnimateProgressWithOptions(
+			logger.AnimatorWithContext(ctx),
+			logger.AnimatorWithMaxLen(maxProgressLength),
+			logger.AnimatorWithMessagef("Cluster status: %s"),
+			logger.AnimatorWithStatusChan(status),
+		)
+	}(animateCtx)
+
+	// Wait for the cluster to be ready, or the context to expire; report status into the status channel
+	var lastStatus string
+	for {
+		clusterStatus := getClusterReadiness(ctx, clientSet)
+		if ctx.Err() != nil {
+			cancel()
+			log.Style(outputIndent, color.FgYellow).ReplaceLinef("Cluster status: %s", lastStatus)
+			return fmt.Errorf("timed out waiting for the cluster to be ready. Last observed status: %s", lastStatus)
+		}
+		status <- clusterStatus
+		if clusterStatus == clusterReadyStatus {
+			cancel()
+			log.Style(outputIndent, color.Faint).ReplaceLinef("Cluster status: %s", clusterStatus)
+			return nil
+		}
+		lastStatus = clusterStatus
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
 		}
-		time.Sleep(1 * time.Second)
 	}
 }
 
+// controlPlaneTaintKeys are the keys of the taints kubeadm registers control plane nodes with. Older
+// Kubernetes versions use the master key.
+var controlPlaneTaintKeys = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+// untaintControlPlanes removes the NoSchedule taints kubeadm registers control plane nodes with, so
+// workloads can be scheduled to them. Other taints of the nodes are kept.
+func untaintControlPlanes(ctx context.Context, clientSet kubernetes.Interface) error {
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes. Error: %s", err.Error())
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		taints := []corev1.Taint{}
+		for _, taint := range node.Spec.Taints {
+			if taint.Effect == corev1.TaintEffectNoSchedule && containsString(controlPlaneTaintKeys, taint.Key) {
+				continue
+			}
+			taints = append(taints, taint)
+		}
+		if len(taints) == len(node.Spec.Taints) {
+			continue
+		}
+
+		node.Spec.Taints = taints
+		_, err = clientSet.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to remove control plane taints from node %s. Error: %s", node.Name, err.Error())
+		}
+		log.Style(outputIndent, color.Faint).Infof("%s\n", node.Name)
+	}
+	return nil
+}
+
+// getClusterReadiness reports whether the API server responds and how many of the cluster's nodes are Ready.
+func getClusterReadiness(ctx context.Context, clientSet kubernetes.Interface) string {
+	_, err := clientSet.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	if err != nil {
+		return "Waiting for API server"
+	}
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "Waiting for API server"
+	}
+
+	ready := 0
+	for i := range nodes.Items {
+		for _, condition := range nodes.Items[i].Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+	if len(nodes.Items) > 0 && ready == len(nodes.Items) {
+		return clusterReadyStatus
+	}
+	return fmt.Sprintf("%d/%d nodes Ready", ready, len(nodes.Items))
+}
+
 func createPackageRepo(pkgClient packages.PackageManager, ns, name, url string) (*v1alpha1.PackageRepository, error) {
 	createdRepo, err := pkgClient.CreatePackageRepo(ns, name, url)
+	if apierrors.IsAlreadyExists(err) {
+		// The repository was created by a previous, interrupted, bootstrap. Its status can
+		// still be resolved by namespace and name.
+		return &v1alpha1.PackageRepository{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+		}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 	return createdRepo, nil
 }
 
-func blockForRepoStatus(repo *v1alpha1.PackageRepository, pkgClient packages.PackageManager) {
-	// Create the parent context and fire a go routine to animate the logging progress
-	ctx, cancel := context.WithCancel(context.Background())
+func blockForRepoStatus(ctx context.Context, repo *v1alpha1.PackageRepository, pkgClient packages.PackageManager) error {
+	// Create the animation context and fire a go routine to animate the logging progress
+	animateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	status := make(chan string, 1)
 	go func(ctx context.Context) {
 		log.Style(outputIndent, color.Reset).AnimateProgressWithOptions(
@@ -744,24 +1867,33 @@ func blockForRepoStatus(repo *v1alpha1.PackageRepository, pkgClient packages.Pac
 			logger.AnimatorWithMessagef("Core package repo status: %s"),
 			logger.AnimatorWithStatusChan(status),
 		)
-	}(ctx)
+	}(animateCtx)
 
-	// Wait for core packages to be running; report status into the status channel
-	// TODO - jpmcb: we need to handle timeouts here
+	// Wait for core packages to be running, or the context to expire; report status into the status channel
+	var lastStatus string
 	for {
-		pkgStatus, err := pkgClient.GetRepositoryStatus(repo.Namespace, repo.Name)
+		pkgStatus, err := pkgClient.GetRepositoryStatus(ctx, repo.Namespace, repo.Name)
+		if ctx.Err() != nil {
+			cancel()
+			log.Style(outputIndent, color.FgYellow).ReplaceLinef("Core package repo status: %s", lastStatus)
+			return fmt.Errorf("timed out waiting for the core package repo to reconcile. Last observed status: %s", lastStatus)
+		}
 		status <- pkgStatus
 		if err != nil {
 			cancel()
-			log.Errorf("failed to check package repository status: %s\n", err.Error())
-			return
+			return err
 		}
 		if pkgStatus == "Reconcile succeeded" {
 			cancel()
 			log.Style(outputIndent, color.Faint).ReplaceLinef("Core package repo status: %s", pkgStatus)
-			break
+			return nil
+		}
+		lastStatus = pkgStatus
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
 		}
-		time.Sleep(1 * time.Second)
 	}
 }
 
@@ -779,25 +1911,22 @@ func installCNI(pkgClient packages.PackageManager, t *UnmanagedCluster) error {
 		log.Errorf("failed to create service account: %s\n", err.Error())
 		return err
 	}
-	var valueData string
-
-	if strings.Contains(t.config.Cni, "antrea") {
-		// TODO(joshrosso): entirely a workaround until we have better plumbing.
-		valueData = `---
-infraProvider: docker
-`
+	valueData, err := buildCNIValues(t.config, t.selectedCNIPkg.valuesSchema)
+	if err != nil {
+		return err
 	}
 
 	cniInstallOpts := packages.PackageInstallOpts{
 		Namespace:      tkgSysNamespace,
-		InstallName:    "cni",
+		InstallName:    cniInstallName,
 		FqPkgName:      t.selectedCNIPkg.fqPkgName,
 		Version:        t.selectedCNIPkg.pkgVersion,
-		Configuration:  []byte(valueData),
+		Configuration:  valueData,
+		Overlays:       cniOverlays(t.config),
 		ServiceAccount: rootSvcAcct.Name,
 	}
 	_, err = pkgClient.CreatePackageInstall(&cniInstallOpts)
-	if err != nil {
+	if err != nil && !apierrors.IsAlreadyExists(err) {
 		return err
 	}
 
@@ -820,16 +1949,25 @@ func ReadClusterContextFromKubeconfig(kcPath string) (string, error) {
 	return ctx, nil
 }
 
-func mergeKubeconfigAndSetContext(mgr kubeconfig.Manager, kcPath, clusterName string) error {
-	err := mgr.MergeToDefaultConfig(kcPath)
+// mergeKubeconfigAndSetContext merges the cluster's kubeconfig into the default kubeconfig and, unless
+// SkipContextSwitch is set, makes the cluster's context the current context.
+func mergeKubeconfigAndSetContext(mgr kubeconfig.Manager, scConfig *config.UnmanagedClusterConfig) error {
+	backupPath, err := mgr.Backup()
+	if err != nil {
+		return fmt.Errorf("failed to back up kubeconfig. Error: %s", err.Error())
+	}
+	if backupPath != "" {
+		log.Style(outputIndent, color.Faint).Infof("Backed up kubeconfig to %s\n", backupPath)
+	}
+	err = mgr.MergeToDefaultConfig(scConfig.KubeconfigPath)
 	if err != nil {
 		log.Errorf("Failed to merge kubeconfig: %s\n", err.Error())
 		return nil
 	}
-	// TODO(joshrosso): we need to resolve this by introspecting the known kubeconfig
-	// 					we cannot assume this syntax will work!
-	kubeContextName := fmt.Sprintf("%s-%s", "kind", clusterName)
-	err = mgr.SetCurrentContext(kubeContextName)
+	if scConfig.SkipContextSwitch {
+		return nil
+	}
+	err = mgr.SetCurrentContext(getKubeContextName(scConfig))
 	if err != nil {
 		return err
 	}
@@ -837,6 +1975,57 @@ func mergeKubeconfigAndSetContext(mgr kubeconfig.Manager, kcPath, clusterName st
 	return nil
 }
 
+// removeKubeContext backs up the default kubeconfig and removes the cluster's context from it. The context
+// of an existing cluster is the user's own and is kept.
+func removeKubeContext(mgr kubeconfig.Manager, scConfig *config.UnmanagedClusterConfig, contextName string) error {
+	if scConfig.ExistingClusterKubeconfig != "" || contextName == "" {
+		return nil
+	}
+	_, err := mgr.Backup()
+	if err != nil {
+		return fmt.Errorf("failed to back up kubeconfig. Error: %s", err.Error())
+	}
+	return mgr.RemoveContext(contextName)
+}
+
+// resolveContextName records the name of the cluster's context once its kubeconfig exists. The
+// provider names the context, unless a ContextName is configured, in which case the context of a
+// cluster created by the provider is renamed to it. When the provider did not return the name of
+// its context, such as for existing or resumed clusters, the current context of the kubeconfig is used.
+func resolveContextName(scConfig *config.UnmanagedClusterConfig, providerContext string) error {
+	current := providerContext
+	if current == "" {
+		var err error
+		current, err = ReadClusterContextFromKubeconfig(scConfig.KubeconfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve kubeconfig context of cluster. Error: %s", err.Error())
+		}
+	}
+	if scConfig.ContextName == "" || scConfig.ContextName == current {
+		scConfig.ContextName = current
+		return nil
+	}
+
+	err := kubeconfig.RenameContext(scConfig.KubeconfigPath, current, scConfig.ContextName)
+	if err != nil {
+		return fmt.Errorf("failed to rename kubeconfig context to %s. Error: %s", scConfig.ContextName, err.Error())
+	}
+	return nil
+}
+
+// getKubeContextName returns the name of the context merged into the kubeconfig for a cluster.
+// Clusters created before the context name was recorded use the current context of their kubeconfig.
+func getKubeContextName(scConfig *config.UnmanagedClusterConfig) string {
+	if scConfig.ContextName != "" {
+		return scConfig.ContextName
+	}
+	if scConfig.KubeconfigPath == "" {
+		return ""
+	}
+	kubeContext, _ := kubeconfig.GetKubeconfigContext(scConfig.KubeconfigPath)
+	return kubeContext
+}
+
 // resolveCNI determines which CNI package to use. It expects to be passed a
 // fully qualified package name except for special known CNI values such as
 // antrea or calico.
@@ -854,6 +2043,7 @@ func resolveCNI(mgr packages.PackageManager, cniName string) (*CNIPackage, error
 		if strings.HasPrefix(pkg.Spec.RefName, cniName) {
 			cniPkg.fqPkgName = pkg.Spec.RefName
 			cniPkg.pkgVersion = pkg.Spec.Version
+			cniPkg.valuesSchema = pkg.Spec.ValuesSchema.OpenAPIv3.Raw
 		}
 	}
 	if err != nil {
@@ -0,0 +1,197 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+)
+
+func TestRunWithContext(t *testing.T) {
+	stepErr := errors.New("download failed")
+	err := runWithContext(context.Background(), func() error {
+		return stepErr
+	})
+	if err != stepErr {
+		t.Errorf("expected the error of the step, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocked := make(chan struct{})
+	defer close(blocked)
+	cancel()
+	err = runWithContext(ctx, func() error {
+		<-blocked
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("expected the context error once it expired, got %v", err)
+	}
+}
+
+func TestUntaintControlPlanes(t *testing.T) {
+	log = logger.NewLogger(false, 0)
+	dedicated := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	clientSet := fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-control-plane"},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+				dedicated,
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-worker"},
+		},
+	)
+
+	err := untaintControlPlanes(context.Background(), clientSet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	node, err := clientSet.CoreV1().Nodes().Get(context.Background(), "test-control-plane", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(node.Spec.Taints) != 1 || node.Spec.Taints[0] != dedicated {
+		t.Errorf("expected only the control plane taints to be removed, got %v", node.Spec.Taints)
+	}
+}
+
+func TestValidateConfigurationSchedulableControlPlanes(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		ClusterName:           "test",
+		TkrLocation:           "projects.registry.vmware.com/tce/tkr:v0.17.0",
+		Provider:              "kind",
+		ContainerRuntime:      "docker",
+		ControlPlaneNodeCount: "3",
+		WorkerNodeCount:       "0",
+	}
+
+	err := validateConfiguration(scConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !scConfig.SchedulableControlPlanes {
+		t.Error("expected control planes to be schedulable when there are no workers")
+	}
+
+	scConfig.WorkerNodeCount = "1"
+	scConfig.SchedulableControlPlanes = false
+	err = validateConfiguration(scConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if scConfig.SchedulableControlPlanes {
+		t.Error("expected control planes not to be schedulable when there are workers")
+	}
+
+	scConfig.Nodes = []config.Node{
+		{Role: config.NodeRoleControlPlane},
+		{Role: config.NodeRoleControlPlane},
+		{Role: config.NodeRoleControlPlane},
+	}
+	err = validateConfiguration(scConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !scConfig.SchedulableControlPlanes {
+		t.Error("expected control planes to be schedulable when the configured nodes have no workers")
+	}
+}
+
+const kindKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: kind-test
+  context:
+    cluster: kind-test
+    user: kind-test
+current-context: kind-test
+users:
+- name: kind-test
+  user:
+    token: secret
+`
+
+func TestResolveContextName(t *testing.T) {
+	kcPath := filepath.Join(t.TempDir(), "kube.conf")
+	err := os.WriteFile(kcPath, []byte(kindKubeconfig), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scConfig := &config.UnmanagedClusterConfig{ClusterName: "test", KubeconfigPath: kcPath}
+	if name := getKubeContextName(scConfig); name != "kind-test" {
+		t.Errorf("expected the current context of the kubeconfig, got %q", name)
+	}
+	err = resolveContextName(scConfig, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if scConfig.ContextName != "kind-test" {
+		t.Errorf("expected the context named by the provider, got %q", scConfig.ContextName)
+	}
+
+	scConfig.ContextName = "dev"
+	err = resolveContextName(scConfig, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kc, err := clientcmd.LoadFromFile(kcPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := kc.Contexts["dev"]; !ok || kc.CurrentContext != "dev" {
+		t.Errorf("expected the context to be renamed to dev, got %v with current context %s", kc.Contexts, kc.CurrentContext)
+	}
+	if _, ok := kc.Contexts["kind-test"]; ok {
+		t.Error("expected the kind-test context to be removed")
+	}
+
+	// Resuming keeps the renamed context
+	err = resolveContextName(scConfig, "")
+	if err != nil || scConfig.ContextName != "dev" {
+		t.Errorf("expected the renamed context to be kept, got %q, error: %v", scConfig.ContextName, err)
+	}
+
+	// minikube names the context after the cluster
+	scConfig = &config.UnmanagedClusterConfig{ClusterName: "test", KubeconfigPath: filepath.Join(t.TempDir(), "missing")}
+	err = resolveContextName(scConfig, "test")
+	if err != nil || scConfig.ContextName != "test" {
+		t.Errorf("expected the context named by the provider, got %q, error: %v", scConfig.ContextName, err)
+	}
+}
+
+func TestValidateConfigurationContextNameExistingCluster(t *testing.T) {
+	scConfig := &config.UnmanagedClusterConfig{
+		ClusterName:               "test",
+		TkrLocation:               "projects.registry.vmware.com/tce/tkr:v0.17.0",
+		Provider:                  "none",
+		ExistingClusterKubeconfig: "kube.conf",
+		ContextName:               "dev",
+	}
+
+	err := validateConfiguration(scConfig)
+	if err == nil {
+		t.Error("expected an error when ContextName is set for an existing cluster")
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/kapp"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/packages"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
+)
+
+// releaseImages are the images of a TKR that make up a cluster.
+type releaseImages struct {
+	nodeImage  string
+	kappBundle string
+	coreRepo   string
+}
+
+// upgradePlan lists the parts of a cluster that differ between two TKRs.
+type upgradePlan struct {
+	from releaseImages
+	to   releaseImages
+	// recreate is set when the node image changed, which can only be applied by recreating the cluster
+	recreate bool
+	// kapp is set when kapp-controller must be re-applied
+	kapp bool
+	// coreRepo is set when the core package repository, and the CNI installed from it, must be updated
+	coreRepo bool
+}
+
+// getReleaseImages reads the images of a TKR from its BOM.
+func getReleaseImages(bom *tkr.Bom) (releaseImages, error) {
+	kappImage, err := bom.GetTKRKappImage()
+	if err != nil {
+		return releaseImages{}, err
+	}
+	return releaseImages{
+		nodeImage:  bom.GetTKRNodeImage(),
+		kappBundle: kappImage.GetRegistryURL(),
+		coreRepo:   bom.GetTKRCoreRepoBundlePath(),
+	}, nil
+}
+
+// planUpgrade compares the images of the TKR a cluster runs with those of the TKR it is upgraded to.
+func planUpgrade(from, to releaseImages) *upgradePlan {
+	return &upgradePlan{
+		from:     from,
+		to:       to,
+		recreate: from.nodeImage != to.nodeImage,
+		kapp:     from.kappBundle != to.kappBundle,
+		coreRepo: from.coreRepo != to.coreRepo,
+	}
+}
+
+// resolveReleaseImages downloads, if needed, and reads the BOM of a TKR.
+func resolveReleaseImages(ctx context.Context, tkrLocation string) (*tkr.Bom, releaseImages, error) {
+	bomFileName, err := getTkrBom(ctx, tkrLocation)
+	if err != nil {
+		return nil, releaseImages{}, fmt.Errorf("failed getting TKR BOM. Error: %s", err.Error())
+	}
+	bom, err := parseTKRBom(bomFileName)
+	if err != nil {
+		return nil, releaseImages{}, fmt.Errorf("failed parsing TKR BOM. Error: %s", err.Error())
+	}
+	images, err := getReleaseImages(bom)
+	if err != nil {
+		return nil, releaseImages{}, fmt.Errorf("failed resolving kapp-controller bundle. Error: %s", err.Error())
+	}
+	return bom, images, nil
+}
+
+// Upgrade moves a cluster to a new TKR. kapp-controller, the core package repository and the CNI
+// are updated in place when the new TKR changes them. A new node image cannot be applied to
+// running nodes, so it is only reported. The cluster's configuration is updated to the new TKR
+// and its node image, so recreating the cluster from it applies the upgrade to the nodes.
+func (t *UnmanagedCluster) Upgrade(ctx context.Context, name, tkrLocation string) error {
+	configPath, err := resolveClusterConfig(name)
+	if err != nil {
+		return err
+	}
+	t.config, err = config.RenderFileToConfig(configPath)
+	if err != nil {
+		return err
+	}
+	t.clusterDirectory, err = resolveClusterDir(name)
+	if err != nil {
+		return err
+	}
+	scConfig := t.config
+
+	if scConfig.FromBundle != "" {
+		return fmt.Errorf("cluster %s was created from bundle %s and cannot be upgraded", name, scConfig.FromBundle)
+	}
+	if scConfig.TkrLocation == tkrLocation {
+		return fmt.Errorf("cluster %s already uses TKR %s", name, tkrLocation)
+	}
+
+	err = configureProxy(scConfig)
+	if err != nil {
+		return fmt.Errorf("failed to configure proxy. Error: %s", err.Error())
+	}
+
+	log.Event(logger.WrenchEmoji, "Resolving current Tanzu Kubernetes Release (TKR)")
+	_, current, err := resolveReleaseImages(ctx, scConfig.TkrLocation)
+	if err != nil {
+		return err
+	}
+	log.Event(logger.WrenchEmoji, "Resolving new Tanzu Kubernetes Release (TKR)")
+	var target releaseImages
+	t.bom, target, err = resolveReleaseImages(ctx, tkrLocation)
+	if err != nil {
+		return err
+	}
+	plan := planUpgrade(current, target)
+
+	if plan.recreate {
+		log.Event(logger.PictureEmoji, "Node image changed")
+		log.Style(outputIndent, color.FgYellow).Warnf("%s -> %s\n", plan.from.nodeImage, plan.to.nodeImage)
+		log.Style(outputIndent, color.FgYellow).Warnf("Running nodes are not upgraded. Recreate the cluster to use the new node image.\n")
+	}
+	if !plan.kapp && !plan.coreRepo {
+		log.Event(logger.PackageEmoji, "kapp-controller and core package repository are unchanged")
+	}
+
+	kcBytes, err := os.ReadFile(scConfig.KubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig of cluster %s. Error: %s", name, err.Error())
+	}
+
+	if plan.kapp {
+		err = resolveKappBundle(t)
+		if err != nil {
+			return fmt.Errorf("failed resolving kapp-controller bundle. Error: %s", err.Error())
+		}
+		kc, err := kapp.New(kcBytes)
+		if err != nil {
+			return fmt.Errorf("failed to create kapp-controller manager, Error: %s", err.Error())
+		}
+		err = t.upgradeKappController(ctx, kc, plan)
+		if err != nil {
+			return err
+		}
+	}
+
+	if plan.coreRepo {
+		err = t.upgradeCoreRepo(ctx, packages.NewClient(kcBytes), plan)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The configuration must describe the nodes the cluster is recreated with, or recreating it
+	// from the configuration would bring back the old node image with the new TKR
+	scConfig.TkrLocation = tkrLocation
+	if plan.recreate {
+		scConfig.NodeImage = plan.to.nodeImage
+	}
+	err = t.saveConfig()
+	if err != nil {
+		return err
+	}
+	log.Style(outputIndent, color.Faint).Infof("Rendered Config: %s\n", configPath)
+
+	return nil
+}
+
+// upgradeKappController re-applies kapp-controller from the resolved bundle of the new TKR and waits
+// for it to be running.
+func (t *UnmanagedCluster) upgradeKappController(ctx context.Context, kc kapp.Manager, plan *upgradePlan) error {
+	log.Event(logger.EnvelopeEmoji, "Upgrading kapp-controller")
+	log.Style(outputIndent, color.Faint).Infof("%s -> %s\n", plan.from.kappBundle, plan.to.kappBundle)
+
+	kappDeployment, err := installKappController(t, kc)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade kapp-controller, Error: %s", err.Error())
+	}
+
+	kappTimeout, err := parseTimeout(t.config.KappTimeout)
+	if err != nil {
+		return err
+	}
+	kappCtx, kappCancel := withTimeout(ctx, kappTimeout)
+	defer kappCancel()
+	return blockForKappStatus(kappCtx, kappDeployment, kc)
+}
+
+// upgradeCoreRepo points the core package repository at the new TKR's and, once it reconciles,
+// moves the CNI to the version the repository provides.
+func (t *UnmanagedCluster) upgradeCoreRepo(ctx context.Context, pkgClient packages.PackageManager, plan *upgradePlan) error {
+	log.Event(logger.EnvelopeEmoji, "Upgrading core package repository")
+	log.Style(outputIndent, color.Faint).Infof("%s -> %s\n", plan.from.coreRepo, plan.to.coreRepo)
+
+	updatedRepo, err := pkgClient.UpdatePackageRepo(tkgSysNamespace, tkgCoreRepoName, plan.to.coreRepo)
+	if err != nil {
+		return fmt.Errorf("failed to update core package repo. Error: %s", err.Error())
+	}
+
+	repoTimeout, err := parseTimeout(t.config.RepoTimeout)
+	if err != nil {
+		return err
+	}
+	repoCtx, repoCancel := withTimeout(ctx, repoTimeout)
+	err = blockForRepoStatus(repoCtx, updatedRepo, pkgClient)
+	repoCancel()
+	if err != nil {
+		return err
+	}
+
+	if t.config.Cni == cniNoneName {
+		return nil
+	}
+	log.Event(logger.GlobeEmoji, "Upgrading CNI")
+	t.selectedCNIPkg, err = resolveCNI(pkgClient, t.config.Cni)
+	if err != nil {
+		log.Style(outputIndent, color.FgYellow).Warnf("CNI not upgraded: %s.\n", err)
+		return nil
+	}
+	log.Style(outputIndent, color.Faint).Infof("%s:%s\n", t.selectedCNIPkg.fqPkgName, t.selectedCNIPkg.pkgVersion)
+	_, err = pkgClient.UpdatePackageInstallVersion(tkgSysNamespace, cniInstallName, t.selectedCNIPkg.pkgVersion)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade the CNI package. Error: %s", err.Error())
+	}
+
+	return blockForPackageInstallStatus(ctx, pkgClient, &config.Package{
+		Namespace:   tkgSysNamespace,
+		InstallName: cniInstallName,
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tanzu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/config"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/kapp"
+	logger "github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/log"
+	"github.com/vmware-tanzu/community-edition/cli/cmd/plugin/unmanaged-cluster/tkr"
+)
+
+const kappManifests = "kind: Deployment"
+
+// fakeImageReader renders a kapp-controller bundle without downloading it. Methods that are not
+// overridden panic.
+type fakeImageReader struct {
+	tkr.ImageReader
+	downloaded bool
+}
+
+func (f *fakeImageReader) DownloadBundleImage() error {
+	f.downloaded = true
+	return nil
+}
+
+func (f *fakeImageReader) AddYttYamlValuesBytes([]byte) error {
+	return nil
+}
+
+func (f *fakeImageReader) SetRelativeConfigPath(string) {}
+
+func (f *fakeImageReader) RenderYaml() ([]byte, error) {
+	return []byte(kappManifests), nil
+}
+
+// fakeKappManager records the manifests kapp-controller is installed with and reports a status.
+type fakeKappManager struct {
+	manifests []byte
+	status    string
+}
+
+func (f *fakeKappManager) Install(opts kapp.InstallOpts) (*v1.Deployment, error) {
+	f.manifests = opts.MergedManifests
+	return &v1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "kapp-controller", Name: "kapp-controller"}}, nil
+}
+
+func (f *fakeKappManager) Status(ctx context.Context, ns, name string) string {
+	return f.status
+}
+
+func upgradeTestPlan() *upgradePlan {
+	return planUpgrade(releaseImages{
+		kappBundle: "projects.registry.vmware.com/tce/kapp-controller-multi-pkg:v0.30.0",
+		coreRepo:   "projects.registry.vmware.com/tce/repo-10:0.10.0",
+	}, releaseImages{
+		kappBundle: "projects.registry.vmware.com/tce/kapp-controller-multi-pkg:v0.31.0",
+		coreRepo:   "projects.registry.vmware.com/tce/repo-11:0.11.0",
+	})
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	from := releaseImages{
+		nodeImage:  "projects.registry.vmware.com/tce/kind:v1.21.5",
+		kappBundle: "projects.registry.vmware.com/tce/kapp-controller-multi-pkg:v0.30.0",
+		coreRepo:   "projects.registry.vmware.com/tce/repo-10:0.10.0",
+	}
+
+	plan := planUpgrade(from, from)
+	if plan.recreate || plan.kapp || plan.coreRepo {
+		t.Errorf("expected no changes between identical releases, got %+v", plan)
+	}
+
+	to := from
+	to.coreRepo = "projects.registry.vmware.com/tce/repo-11:0.11.0"
+	plan = planUpgrade(from, to)
+	if plan.recreate || plan.kapp || !plan.coreRepo {
+		t.Errorf("expected only the core repository to change, got %+v", plan)
+	}
+
+	to.nodeImage = "projects.registry.vmware.com/tce/kind:v1.22.4"
+	to.kappBundle = "projects.registry.vmware.com/tce/kapp-controller-multi-pkg:v0.31.0"
+	plan = planUpgrade(from, to)
+	if !plan.recreate || !plan.kapp || !plan.coreRepo {
+		t.Errorf("expected all parts to change, got %+v", plan)
+	}
+	if plan.from.nodeImage != from.nodeImage || plan.to.nodeImage != to.nodeImage {
+		t.Errorf("expected the plan to keep both node images, got %+v", plan)
+	}
+}
+
+func TestUpgradeKappController(t *testing.T) {
+	log = logger.NewLogger(false, 0)
+	bundle := &fakeImageReader{}
+	uc := &UnmanagedCluster{
+		config:               &config.UnmanagedClusterConfig{KappTimeout: "5s"},
+		kappControllerBundle: bundle,
+	}
+	kc := &fakeKappManager{status: "Running"}
+
+	err := uc.upgradeKappController(context.Background(), kc, upgradeTestPlan())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bundle.downloaded || string(kc.manifests) != kappManifests {
+		t.Errorf("expected kapp-controller to be installed from the new bundle, got manifests %q", kc.manifests)
+	}
+
+	uc.config.KappTimeout = "10ms"
+	kc.status = "ContainerCreating"
+	err = uc.upgradeKappController(context.Background(), kc, upgradeTestPlan())
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for kapp-controller") {
+		t.Errorf("expected a timeout waiting for kapp-controller, got: %v", err)
+	}
+}
+
+func TestUpgradeCoreRepo(t *testing.T) {
+	log = logger.NewLogger(false, 0)
+	pkgClient := &fakePackageManager{
+		packages:      map[string][]string{tkgSysNamespace: {"antrea.community.tanzu.vmware.com"}},
+		versions:      map[string]string{"antrea.community.tanzu.vmware.com": "1.2.3"},
+		repoStatus:    "Reconcile succeeded",
+		installStatus: packageReconcileSucceeded,
+	}
+	uc := &UnmanagedCluster{config: &config.UnmanagedClusterConfig{Cni: "antrea", RepoTimeout: "5s"}}
+	plan := upgradeTestPlan()
+
+	err := uc.upgradeCoreRepo(context.Background(), pkgClient, plan)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if url := pkgClient.repoURLs[tkgSysNamespace+"/"+tkgCoreRepoName]; url != plan.to.coreRepo {
+		t.Errorf("expected the core repository to point at %s, got %q", plan.to.coreRepo, url)
+	}
+	if version := pkgClient.installVersions[tkgSysNamespace+"/"+cniInstallName]; version != "1.2.3" {
+		t.Errorf("expected the CNI to be upgraded to 1.2.3, got %q", version)
+	}
+
+	// The CNI is left alone when there is none
+	pkgClient.installVersions = nil
+	uc.config.Cni = cniNoneName
+	err = uc.upgradeCoreRepo(context.Background(), pkgClient, plan)
+	if err != nil || len(pkgClient.installVersions) != 0 {
+		t.Errorf("expected only the core repository to be upgraded, got %v, error: %v", pkgClient.installVersions, err)
+	}
+
+	uc.config.RepoTimeout = "10ms"
+	pkgClient.repoStatus = "Reconciling"
+	err = uc.upgradeCoreRepo(context.Background(), pkgClient, plan)
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for the core package repo") {
+		t.Errorf("expected a timeout waiting for the core package repository, got: %v", err)
+	}
+}
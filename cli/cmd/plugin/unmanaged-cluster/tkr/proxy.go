@@ -0,0 +1,33 @@
+// Copyright 2022 VMware Tanzu Community Edition contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkr
+
+import (
+	"os"
+	"strings"
+)
+
+// SetProxy configures the imgpkg and kbld calls made for images to connect to registries through
+// the proxies. Empty proxies are left unset. As the libraries read the proxy environment variables
+// of the process once, SetProxy must be called before any image is downloaded. Child processes,
+// such as those creating the cluster's nodes, inherit the proxies.
+func SetProxy(httpProxy, httpsProxy, noProxy string) error {
+	envs := map[string]string{
+		"HTTP_PROXY":  httpProxy,
+		"HTTPS_PROXY": httpsProxy,
+		"NO_PROXY":    noProxy,
+	}
+	for name, value := range envs {
+		if value == "" {
+			continue
+		}
+		for _, key := range []string{name, strings.ToLower(name)} {
+			err := os.Setenv(key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -479,6 +479,10 @@ func (tkr *Bom) GetTKRNodeImage() string {
 	return fmt.Sprintf("%s/%s:%s", repo, path, tag)
 }
 
+func (tkr *Bom) GetTKRKubernetesVersion() string {
+	return tkr.KubeadmConfigSpec.KubernetesVersion
+}
+
 func (tkr *Bom) GetTKRCoreRepoBundlePath() string {
 	registry := tkr.Components.TkgCorePackages[0].Images.TanzuCorePackageRepositoryImage.Repository
 	if registry == "" {